@@ -1,75 +1,278 @@
 grammar Vega;
 
 block
-    :   (FUNC ID LBRACKET functionParameterDeclaration? RBRACKET functionReturnType scopeStatement)+ EOF
+    :   importDeclaration* (FUNC ID typeParameters? LBRACKET functionParameterDeclaration? RBRACKET ARROW? functionReturnType scopeStatement | structDeclaration | enumDeclaration | globalDeclaration)+ EOF
+    ;
+
+// the module is resolved relative to the project root and the search path, its exported declarations are qualified
+// with the last element of the path
+importDeclaration
+    :   IMPORT LITERAL DELIMITER
+    ;
+
+// globals are initialized in declaration order unless their initializer depends on later globals, constants need
+// constant initializers
+globalDeclaration
+    :   CONST? declarationTargets (ASSIGN values)? DELIMITER
+    |   annotatedDeclaration DELIMITER
+    ;
+
+// structs have to be declared before they are used, the delimiter of the last field can be omitted
+structDeclaration
+    :   STRUCT ID LCURLY (variableType ID DELIMITER)+ RCURLY
+    |   STRUCT ID LCURLY (ID COLON variableType DELIMITER)+ RCURLY
+    ;
+
+// the variants of an enum are qualified with its name, e.g. Color.Red, values of an enum can only be compared
+enumDeclaration
+    :   ENUM ID LCURLY ID (COMMA ID)* COMMA? RCURLY
+    ;
+
+variant
+    :   ID DOT ID
+    ;
+
+// type parameters are constrained to ordered or numeric types, the type arguments are inferred from the arguments of a
+// call and every instance is generated with concrete types. Generic functions are declared before they are called.
+typeParameters
+    :   LARRAY ID (COLON ID)? (COMMA ID (COLON ID)?)* RARRAY
     ;
 
 functionParameterDeclaration
 	:   functionParameterDefinition (COMMA functionParameterDefinition)*
     ;
 
+// array parameters are slices which share the elements of the passed array, arrays are copied on assignment. Trailing
+// parameters can have constant default values, they can be omitted in calls of the function.
 functionParameterDefinition
-    :   terminalVariableType (LARRAY RARRAY)* ID
+    :   terminalVariableType (LARRAY RARRAY)* ID (ASSIGN booleanExpression)?
+    |   ID COLON parameterType (ASSIGN booleanExpression)?
     ;
 
+// functions with multiple results return a tuple which has to be destructured by the caller
 functionReturnType
+    :   parameterType
+    |   LBRACKET parameterType (COMMA parameterType)* RBRACKET
+    ;
+
+parameterType
     :   terminalVariableType (LARRAY RARRAY)*
     ;
 
+// slices are declared without size in front of the array dimensions, int[][3] is a slice of arrays of three integers
+variableType
+    :   terminalVariableType (LARRAY RARRAY)* (LARRAY INT RARRAY)*
+    ;
+
 scopeStatement
     :   LCURLY ((PASS DELIMITER) | (statement)+) RCURLY
     ;
 
+// break is only allowed inside loops and switches, continue only inside loops. Every path through a function body has
+// to end with a return statement or an endless loop, statements after return, break or continue are reported as
+// unreachable.
 statement
-	:	CONST? terminalVariableType (LARRAY INT RARRAY)* ID (ASSIGN booleanExpression)? DELIMITER
-	|   ID arrayAccess* ASSIGN booleanExpression DELIMITER
-	|   ID funcCall
-	|   RETURN booleanExpression DELIMITER
+	:	declaration DELIMITER
+	|   inferredDeclaration DELIMITER
+	|   assignmentOrCall DELIMITER
+	|   dereferenceAssignment DELIMITER
+	|   try DELIMITER
+	|   RETURN values DELIMITER
 	|   CONTINUE DELIMITER
 	|   BREAK DELIMITER
 	|	WHILE conditionalScope
+	|   forStatement
 	|	IF conditionalScope (ELIF conditionalScope)* (ELSE scopeStatement)?
-	|   SWITCH expression LCURLY (CASE terminal COLON statement+)+ (DEFAULT COLON statement+)? RCURLY
+	|   switchStatement
 	;
 
+declaration
+    :   CONST? declarationTargets (ASSIGN values)?
+    |   annotatedDeclaration
+    ;
+
+// language version 2 annotates the names with their types instead of declarations with the type in front of the names,
+// the return type of functions follows an arrow. A single type applies to all names, otherwise each name has its own type.
+annotatedDeclaration
+    :   ID (COMMA ID)* COLON CONST? variableType (COMMA variableType)* (ASSIGN values)?
+    ;
+
+// a name without type has the type of the previous name, a single value initializes all declared variables and the
+// blank identifier _ discards a value
+declarationTargets
+    :   variableType ID (COMMA variableType? ID)*
+    ;
+
+// var declares variables and let declares constants with the types of their values, empty array literals need a
+// declaration with type
+inferredDeclaration
+    :   (VAR | LET) ID (COMMA ID)* ASSIGN values
+    ;
+
+values
+    :   booleanExpression (COMMA booleanExpression)*
+    ;
+
+// functions can be called through variables, fields and elements of function type
+assignmentOrCall
+    :   ID (arrayAccess | fieldAccess | funcCall)* assignmentOperator
+    |   ID (arrayAccess | fieldAccess)* (COMMA ID (arrayAccess | fieldAccess)*)+ ASSIGN values
+    |   (ID | qualifiedIdentifier) (arrayAccess | fieldAccess | funcCall)* funcCall
+    ;
+
+// statements starting with two stars are dereferences, pointers to pointers are declared with var
+dereferenceAssignment
+    :   dereference assignmentOperator
+    ;
+
+// compound assignments combine the target and the value with the operator, ++ and -- add or subtract one from a number
+assignmentOperator
+    :   (ASSIGN | PLUSASSIGN | MINUSASSIGN | MULTASSIGN | DIVASSIGN | MODASSIGN | ANDASSIGN | ORASSIGN | XORASSIGN | SHLASSIGN | SHRASSIGN) booleanExpression
+    |   INC
+    |   DEC
+    ;
+
+// loop variables are only visible inside the loop, a range loop with a single variable iterates over the elements
+forStatement
+    :   FOR (declaration | inferredDeclaration | assignmentOrCall)? DELIMITER booleanExpression? DELIMITER assignmentOrCall? scopeStatement
+    |   FOR ID (COMMA ID)? IN booleanExpression scopeStatement
+    ;
+
+// case values have to match the type of the switch expression and must be unique, a case only continues with the next
+// case when it ends with fallthrough
+// a switch on an enum without default case has to handle all variants
+switchStatement
+    :   SWITCH expression LCURLY (CASE caseValue (COMMA caseValue)* COLON caseBody)+ (DEFAULT COLON caseBody)? RCURLY
+    ;
+
+caseValue
+    :   terminal
+    |   variant
+    ;
+
+caseBody
+    :   statement+ (FALLTHROUGH DELIMITER)?
+    |   FALLTHROUGH DELIMITER
+    ;
+
 conditionalScope
     :   booleanExpression scopeStatement
     ;
 
+// and binds tighter than or, the right operand of and and or is only evaluated when the left operand does not
+// determine the result (short-circuit evaluation). The operands have to be booleans.
 booleanExpression
-    :   comparisonExpression ((OR | AND) comparisonExpression)*
+    :   andExpression (OR andExpression)*
     ;
 
+andExpression
+    :   notExpression (AND notExpression)*
+    ;
+
+// not binds weaker than comparisons, not a < b negates the comparison
+notExpression
+    :   NOT notExpression
+    |   comparisonExpression
+    ;
+
+// comparisons cannot be chained, a < b < c has to be written as a < b and b < c
 comparisonExpression
-    :   expression (comparisonOperator expression)*
+    :   expression (comparisonOperator expression)?
     ;
 
+// arithmetic operators need operands of the same type, int is promoted to float when the other operand is a float
+// and strings can be concatenated with PLUS. Narrowing conversions (float to int or char, int to char) are only
+// possible with an explicit conversion.
+// the precedence of the operators follows Go, shifts and & bind like multiplications, | and ^ like additions. The
+// remainder, bitwise and shift operators can only be applied to integers.
 expression
-    :   term ((PLUS | MINUS) term)*
+    :   term ((PLUS | MINUS | BITOR | BITXOR) term)*
     ;
 
 term
-    :	factor ((MULT | DIV) factor)*
+    :	factor ((MULT | DIV | MOD | BITAND | SHL | SHR) factor)*
 	;
 
 factor
-    :   (MINUS  |NOT)? unary
+    :   (MINUS | BITNOT)? unary
+    |   dereference
+    |   address
+    |   try
+    ;
+
+// try is the value of a successful result, a failure is returned by the enclosing function which has to return a result
+try
+    :   TRY unary
+    ;
+
+// dereferencing nil is an error, fields of a struct are accessed through a pointer without dereferencing it
+dereference
+    :   MULT factor
+    ;
+
+// the addresses of constants, functions and results of calls cannot be taken. References to local variables cannot
+// be returned, stored in global variables or stored through pointers.
+address
+    :   BITAND ID (arrayAccess | fieldAccess)*
     ;
 
 unary
     :   terminal
-    |   ID arrayAccess? // potential array access
-    |   ID funcCall
+    |   (ID | qualifiedIdentifier) (arrayAccess | fieldAccess | funcCall)* // potential array or field access or call
+    |   functionLiteral
+    |   conversion
+    |   variant
+    |   structLiteral
     |   LBRACKET booleanExpression RBRACKET
-    |   LARRAY (expression (COMMA expression)*)? RARRAY
+    |   LARRAY (expression (COMMA expression)*)? RARRAY // the elements have a common type
+    |   stringInterpolation
     ;
 
+// numbers and chars can be converted into each other, floats are truncated when they are converted into integers
+conversion
+    :   (INT_TYPE | FLOAT_TYPE | CHAR_TYPE | BOOL_TYPE) LBRACKET booleanExpression RBRACKET
+    ;
+
+// function literals capture the variables of the enclosing functions by reference, return leaves the literal
+functionLiteral
+    :   FUNC LBRACKET functionParameterDeclaration? RBRACKET ARROW functionReturnType scopeStatement
+    ;
+
+// the lexer splits interpolated literals "a ${b} c" into INTERP_START, INTERP_MID and INTERP_END parts
+stringInterpolation
+    :   INTERP_START booleanExpression (INTERP_MID booleanExpression)* INTERP_END
+    ;
+
+// functions of the prelude (print, println, len, cap, append, substr, concat, find, to_int, to_str, sqrt, pow, abs,
+// floor, min, max) are called like declared functions, a declaration with the same name hides them. Only the functions
+// with a fixed signature (substr, concat, find, to_int, sqrt, pow, floor) can be used as function values.
 funcCall
-    :   LBRACKET ( booleanExpression (COMMA booleanExpression)*)? RBRACKET
+    :   LBRACKET ( argument (COMMA argument)*)? RBRACKET
     ;
 
+// named arguments follow the positional arguments, they can only be used in direct calls of declared functions
+argument
+    :   (ID COLON)? booleanExpression
+    ;
+
+// slicing an array or a slice results in a slice, slicing a str in a str
 arrayAccess
     :   LARRAY expression RARRAY
+    |   LARRAY expression? COLON expression? RARRAY
+    ;
+
+fieldAccess
+    :   DOT ID
+    ;
+
+// only declarations starting with an uppercase letter can be used by importing modules
+qualifiedIdentifier
+    :   ID DOT ID
+    ;
+
+// the identifier has to be the name of a declared struct
+structLiteral
+    :   ID LCURLY (ID COLON booleanExpression (COMMA ID COLON booleanExpression)* COMMA?)? RCURLY
     ;
 
 // Terminals
@@ -77,7 +280,9 @@ terminal
     :   INT
     |   FLOAT
     |   BOOL
+    |   NIL
     |   LITERAL
+    |   RAW_LITERAL
     |   CHAR
     ;
 terminalVariableType
@@ -86,6 +291,25 @@ terminalVariableType
     |   STRING_TYPE
     |   CHAR_TYPE
     |   BOOL_TYPE
+    |   ID // name of a declared struct
+    |   functionType
+    |   pointerType
+    |   resultType
+    ;
+
+// a result holds a value or the message of a failure, r.ok reports which one and r.value and r.error read them
+resultType
+    :   RESULT_TYPE LARRAY parameterType RARRAY
+    ;
+
+// a struct can point to itself, *int[3] is an array of three pointers
+pointerType
+    :   MULT terminalVariableType
+    ;
+
+// function values are called with arguments of the parameter types and cannot be compared
+functionType
+    :   FUNC LBRACKET (parameterType (COMMA parameterType)*)? RBRACKET ARROW functionReturnType
     ;
 comparisonOperator
     :   EQUAL
@@ -110,6 +334,66 @@ MULT
 DIV
     :   '/'
     ;
+MOD
+    :   '%'
+    ;
+BITAND
+    :   '&'
+    ;
+BITOR
+    :   '|'
+    ;
+BITXOR
+    :   '^'
+    ;
+BITNOT
+    :   '~'
+    ;
+SHL
+    :   '<<'
+    ;
+SHR
+    :   '>>'
+    ;
+INC
+    :   '++'
+    ;
+DEC
+    :   '--'
+    ;
+PLUSASSIGN
+    :   '+='
+    ;
+MINUSASSIGN
+    :   '-='
+    ;
+MULTASSIGN
+    :   '*='
+    ;
+DIVASSIGN
+    :   '/='
+    ;
+MODASSIGN
+    :   '%='
+    ;
+ANDASSIGN
+    :   '&='
+    ;
+ORASSIGN
+    :   '|='
+    ;
+XORASSIGN
+    :   '^='
+    ;
+SHLASSIGN
+    :   '<<='
+    ;
+SHRASSIGN
+    :   '>>='
+    ;
+ARROW
+    :   '->'
+    ;
 LBRACKET
     :   '('
     ;
@@ -134,6 +418,9 @@ COLON
 COMMA
     :   ','
     ;
+DOT
+    :   '.'
+    ;
 ASSIGN
     :   '='
     ;
@@ -155,6 +442,8 @@ GREATEREQ
 NOTEQUAL
     :   '!='
     ;
+// a line break is only a delimiter when it follows an identifier, a literal, ')', ']', '}', 'return', 'break',
+// 'continue', 'pass', 'fallthrough', '++' or '--' and is not enclosed in '(' or '[', otherwise it is skipped like other whitespaces
 DELIMITER
     :   ';'
     |   '\n'
@@ -164,12 +453,33 @@ DELIMITER
 CONST
     :   'const'
     ;
+VAR
+    :   'var'
+    ;
+LET
+    :   'let'
+    ;
 FUNC
     :   'func'
     ;
+STRUCT
+    :   'struct'
+    ;
+ENUM
+    :   'enum'
+    ;
+IMPORT
+    :   'import'
+    ;
 WHILE
     :   'while'
     ;
+FOR
+    :   'for'
+    ;
+IN
+    :   'in'
+    ;
 IF
     :   'if'
     ;
@@ -188,9 +498,15 @@ CASE
 DEFAULT
     :   'default'
     ;
+FALLTHROUGH
+    :   'fallthrough'
+    ;
 RETURN
     :   'return'
     ;
+TRY
+    :   'try'
+    ;
 PASS
     :   'pass'
     ;
@@ -209,6 +525,9 @@ FLOAT_TYPE
 STRING_TYPE
     :   'str'
     ;
+RESULT_TYPE
+    :   'Result'
+    ;
 CHAR_TYPE
     :   'char'
     ;
@@ -237,6 +556,10 @@ BOOL
     |   'false'
     ;
 
+NIL
+    :   'nil'
+    ;
+
 INT :	'0'..'9'+
     ;
 
@@ -250,6 +573,22 @@ LITERAL
     :  '"' ( ESC_SEQ | ~('\\'|'"') )* '"'
     ;
 
+RAW_LITERAL
+    :  '`' ~('`')* '`'
+    ;
+
+INTERP_START
+    :  '"' ( ESC_SEQ | ~('\\'|'"'|'$') )* '${'
+    ;
+
+INTERP_MID
+    :  '}' ( ESC_SEQ | ~('\\'|'"'|'$') )* '${'
+    ;
+
+INTERP_END
+    :  '}' ( ESC_SEQ | ~('\\'|'"'|'$') )* '"'
+    ;
+
 CHAR:  '\'' ( ESC_SEQ | ~('\''|'\\') ) '\''
     ;
 
@@ -261,11 +600,17 @@ HEX_DIGIT : ('0'..'9'|'a'..'f'|'A'..'F') ;
 
 fragment
 ESC_SEQ
-    :   '\\' ('b'|'t'|'n'|'f'|'r'|'\\"'|'\''|'\\')
+    :   '\\' ('b'|'t'|'n'|'f'|'r'|'\\"'|'\''|'\\'|'$')
+    |   HEX_ESC
     |   UNICODE_ESC
     |   OCTAL_ESC
     ;
 
+fragment
+HEX_ESC
+    :   '\\' 'x' HEX_DIGIT HEX_DIGIT
+    ;
+
 fragment
 OCTAL_ESC
     :   '\\' ('0'..'3') ('0'..'7') ('0'..'7')
@@ -276,6 +621,8 @@ OCTAL_ESC
 fragment
 UNICODE_ESC
     :   '\\' 'u' HEX_DIGIT HEX_DIGIT HEX_DIGIT HEX_DIGIT
+    |   '\\' 'U' HEX_DIGIT HEX_DIGIT HEX_DIGIT HEX_DIGIT HEX_DIGIT HEX_DIGIT HEX_DIGIT HEX_DIGIT
+    |   '\\' 'u' '{' HEX_DIGIT HEX_DIGIT? HEX_DIGIT? HEX_DIGIT? HEX_DIGIT? HEX_DIGIT? '}'
     ;
 
 WS : [ \t\n]+ -> skip;
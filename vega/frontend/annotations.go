@@ -0,0 +1,138 @@
+package frontend
+
+import (
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// postfixTypes checks if the declarations of the parsed file annotate the names with their types. Version 1 declares
+// the type in front of the name, e.g. int a = 1. From version 2 on the type follows the names and a colon, e.g.
+// a: int = 1, and functions declare their results after an arrow, e.g. func f(a: int) -> int.
+func (parser *parser) postfixTypes() bool {
+	return parser.languageVersion() >= Version2
+}
+
+// lookAHeadDeclaration checks if the next token starts the declaration of a global variable, at top level func starts
+// a function declaration and not a function type
+func (parser *parser) lookAHeadDeclaration() bool {
+	if parser.postfixTypes() {
+		return parser.lookAHead(tokens.ID)
+	}
+	return parser.lookAHead(tokens.CONST) || (parser.lookAHeadType() && !parser.lookAHead(tokens.FUNC))
+}
+
+// lookAHeadParameter checks if the next token starts the definition of a function parameter
+func (parser *parser) lookAHeadParameter() bool {
+	if parser.postfixTypes() {
+		return parser.lookAHead(tokens.ID)
+	}
+	return parser.lookAHeadType()
+}
+
+// parseNames parses the comma separated names of a declaration, the first name is the current token. Global variables
+// are added to the top level declarations of the package.
+func (parser *parser) parseNames(global bool) ([]*target, error) {
+	var targets []*target
+	for {
+		declared := &target{name: parser.currentToken.GetToken().(tokens.IWord).GetLexeme()}
+		if global && declared.name != blank {
+			var err error
+			if declared.declaration, err = parser.declare(true, false); err != nil {
+				return nil, err
+			}
+		}
+		targets = append(targets, declared)
+		if !parser.lookAHead(tokens.COMMA) {
+			return targets, nil
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+		}
+	}
+}
+
+// parseAnnotation parses the types of the declared names and reports if they are constants. Either a single type
+// applies to all names or each name has its own type.
+//
+// annotation
+//   : COLON CONST? variableType (COMMA variableType)*
+//   ;
+func (parser *parser) parseAnnotation(parserInterface Parser, targets []*target) (bool, error) {
+	if !parser.matchToken(tokens.COLON) {
+		return false, parser.syntaxError("Mismatched input '%v', expected ',' or ':'")
+	}
+	constant := parser.lookAHead(tokens.CONST)
+	if constant {
+		if !parser.matchToken(tokens.CONST) {
+			return false, parser.syntaxError("lexicalError")
+		}
+	}
+	var types []language.IBasicType
+	for {
+		varType, err := parserInterface.parseVariableType(parserInterface)
+		if err != nil {
+			return false, err
+		}
+		types = append(types, varType)
+		if !parser.lookAHead(tokens.COMMA) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return false, parser.syntaxError("lexicalError")
+		}
+	}
+	if len(types) != 1 && len(types) != len(targets) {
+		return false, parser.typeError("Wrong number of types in declaration, expected 1 or %v", len(targets))
+	}
+	for i, declared := range targets {
+		declared.varType = types[0]
+		if len(types) > 1 {
+			declared.varType = types[i]
+		}
+		if declared.declaration != nil {
+			declared.declaration.constant = constant
+		}
+	}
+	return constant, nil
+}
+
+// parseDeclarationOrAssignment parses a statement starting with an identifier, the identifier is the current token.
+// With type annotations a list of names is a declaration when it is followed by a colon, otherwise the names are the
+// targets of an assignment.
+//
+// annotatedDeclaration
+//   : ID (COMMA ID)* annotation (ASSIGN values)?
+//   ;
+func (parser *parser) parseDeclarationOrAssignment(parserInterface Parser) error {
+	if !parser.postfixTypes() || !(parser.lookAHead(tokens.COLON) || parser.lookAHead(tokens.COMMA)) {
+		return parserInterface.parseAssignmentOrCall(parserInterface)
+	}
+	names, err := parser.parseNames(false)
+	if err != nil {
+		return err
+	}
+	if parser.lookAHead(tokens.COLON) {
+		constant, err := parser.parseAnnotation(parserInterface, names)
+		if err != nil {
+			return err
+		}
+		return parser.parseDeclarationValues(parserInterface, names, constant)
+	}
+	// the names in front of the last one are variables, the last name can be followed by an element or field access
+	var targets []language.IBasicType
+	for _, name := range names[:len(names)-1] {
+		targetType, err := parser.variableType(name.name)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, targetType)
+	}
+	last, err := parser.parseAssignmentTarget(parserInterface)
+	if err != nil {
+		return err
+	}
+	return parser.parseDestructuringAssignment(parserInterface, append(targets, last))
+}
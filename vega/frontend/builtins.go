@@ -0,0 +1,204 @@
+package frontend
+
+import (
+	"govega/vega/frontend/utils"
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// builtin describes a function of the prelude. Functions with a fixed signature are checked against the parameter types
+// of their symbol and can be used as function values. The result type of the other functions depends on the types of
+// their arguments, therefore they are checked by dedicated functions and have to be called directly.
+type builtin struct {
+	params []language.IBasicType // parameters of a function with fixed signature
+	result language.IBasicType   // result of a function with fixed signature
+	// check validates the arguments of a function without fixed signature and returns the result type
+	check func(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error)
+}
+
+// stringType is the type of the str parameters and results of the prelude
+var stringType = language.NewString(0)
+
+// prelude maps the names of the built-in functions to their signatures
+var prelude = map[string]builtin{
+	"print":   {check: builtinPrint("print")},
+	"println": {check: builtinPrint("println")},
+	"len":     {check: builtinLen},
+	"cap":     {check: builtinCap},
+	"append":  {check: builtinAppend},
+	"substr":  {params: []language.IBasicType{stringType, language.IntType, language.IntType}, result: stringType},
+	"concat":  {params: []language.IBasicType{stringType, stringType}, result: stringType},
+	"find":    {params: []language.IBasicType{stringType, stringType}, result: language.IntType},
+	"to_int":  {params: []language.IBasicType{stringType}, result: language.IntType},
+	"to_str":  {check: builtinToStr},
+	"sqrt":    {params: []language.IBasicType{language.FloatType}, result: language.FloatType},
+	"pow":     {params: []language.IBasicType{language.FloatType, language.FloatType}, result: language.FloatType},
+	"floor":   {params: []language.IBasicType{language.FloatType}, result: language.FloatType},
+	"abs":     {check: builtinAbs},
+	"min":     {check: builtinMinMax("min")},
+	"max":     {check: builtinMinMax("max")},
+	// functions which can fail return a result instead of stopping the program
+	"ok":          {check: builtinOk},
+	"fail":        {params: []language.IBasicType{stringType}, result: failure},
+	"parse_int":   {params: []language.IBasicType{stringType}, result: language.NewResult(language.IntType)},
+	"parse_float": {params: []language.IBasicType{stringType}, result: language.NewResult(language.FloatType)},
+}
+
+// newPackageTable creates the symbol table of a package with the prelude in its global scope, declarations with the same
+// name hide the built-in functions
+func newPackageTable() *utils.SymbolTable {
+	table := utils.NewSymbolTable()
+	for name, function := range prelude {
+		symbol := utils.NewSymbol(name, function.result, true, false)
+		symbol.Params = function.params
+		symbol.Builtin = true
+		table.Add(symbol)
+	}
+	return table
+}
+
+// builtinCall checks the call of a built-in function without fixed signature and returns its result type
+func (parser *parser) builtinCall(name string, argTypes []language.IBasicType) (language.IBasicType, error) {
+	// the results of built-in functions are only known when the program is executed
+	if parser.declaration != nil {
+		parser.declaration.dynamic = true
+	}
+	return prelude[name].check(parser, argTypes)
+}
+
+// argumentCountError returns a vega error on calls of built-in functions with a wrong number of arguments
+func (parser *parser) argumentCountError(name string, count string) error {
+	return parser.typeError("Wrong number of arguments in call of '%v', expected %v", name, count)
+}
+
+// builtinPrint writes the values to the standard output, println terminates the output with a line break
+//
+// print(values...)
+// println(values...)
+func builtinPrint(name string) func(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	return func(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+		for _, argType := range argTypes {
+			if !printable(argType) {
+				return nil, parser.typeError("Mismatched type '%v' in call of '%v', expected printable type", argType, name)
+			}
+		}
+		return nil, nil
+	}
+}
+
+// builtinLen returns the number of elements of an array or slice, or the number of characters of a str
+//
+// len(array | slice | str) int
+func builtinLen(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	if len(argTypes) != 1 {
+		return nil, parser.argumentCountError("len", "1")
+	}
+	switch argTypes[0].(type) {
+	case nil, *language.ArrayType, *language.SliceType, *language.StringType:
+		return language.IntType, nil
+	default:
+		return nil, parser.typeError("Mismatched type '%v' in call of 'len', expected array, slice or str", argTypes[0])
+	}
+}
+
+// builtinCap returns the number of elements of an array or the number of elements a slice can grow to without
+// allocating a new array
+//
+// cap(array | slice) int
+func builtinCap(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	if len(argTypes) != 1 {
+		return nil, parser.argumentCountError("cap", "1")
+	}
+	switch argTypes[0].(type) {
+	case nil, *language.ArrayType, *language.SliceType:
+		return language.IntType, nil
+	default:
+		return nil, parser.typeError("Mismatched type '%v' in call of 'cap', expected array or slice", argTypes[0])
+	}
+}
+
+// builtinAppend returns a slice with the values added to the end of the given slice. The elements are shared with the
+// given slice unless its capacity is exceeded and a new array has to be allocated.
+//
+// append(T[] slice, T values...) T[]
+func builtinAppend(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	if len(argTypes) == 0 {
+		return nil, parser.argumentCountError("append", "at least 1")
+	}
+	switch slice := argTypes[0].(type) {
+	case nil:
+		return nil, nil
+	case *language.SliceType:
+		for _, valueType := range argTypes[1:] {
+			if !assignable(slice.GetElementType(), valueType) {
+				return nil, parser.typeError("Mismatched type '%v' in call of 'append', expected '%v'", valueType, slice.GetElementType())
+			}
+		}
+		return slice, nil
+	default:
+		return nil, parser.typeError("Mismatched type '%v' in call of 'append', expected slice", argTypes[0])
+	}
+}
+
+// builtinToStr converts a value of a basic type into its string representation
+//
+// to_str(value) str
+func builtinToStr(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	if len(argTypes) != 1 {
+		return nil, parser.argumentCountError("to_str", "1")
+	}
+	if !printable(argTypes[0]) {
+		return nil, parser.typeError("Mismatched type '%v' in call of 'to_str', expected printable type", argTypes[0])
+	}
+	return stringType, nil
+}
+
+// builtinOk returns a successful result holding the value
+//
+// ok(T value) Result[T]
+func builtinOk(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	if len(argTypes) != 1 {
+		return nil, parser.argumentCountError("ok", "1")
+	}
+	if _, ok := argTypes[0].(*language.TupleType); ok {
+		return nil, parser.typeError("Multiple values '%v' in single-value context", argTypes[0])
+	}
+	if argTypes[0] == nil {
+		return nil, nil
+	}
+	return language.NewResult(argTypes[0]), nil
+}
+
+// builtinAbs returns the absolute value of a number
+//
+// abs(int) int
+// abs(float) float
+func builtinAbs(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	if len(argTypes) != 1 {
+		return nil, parser.argumentCountError("abs", "1")
+	}
+	if !numeric(argTypes[0]) {
+		return nil, parser.typeError("Mismatched type '%v' in call of 'abs', expected 'int' or 'float'", argTypes[0])
+	}
+	return argTypes[0], nil
+}
+
+// builtinMinMax returns the smaller or the larger of two numbers, the result is a float if one of the numbers is a
+// float
+//
+// min(a, b)
+// max(a, b)
+func builtinMinMax(name string) func(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+	return func(parser *parser, argTypes []language.IBasicType) (language.IBasicType, error) {
+		if len(argTypes) != 2 {
+			return nil, parser.argumentCountError(name, "2")
+		}
+		for _, argType := range argTypes {
+			if !numeric(argType) {
+				return nil, parser.typeError("Mismatched type '%v' in call of '%v', expected 'int' or 'float'", argType, name)
+			}
+		}
+		result, _ := binaryType(tokens.ADD, argTypes[0], argTypes[1])
+		return result, nil
+	}
+}
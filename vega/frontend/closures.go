@@ -0,0 +1,135 @@
+package frontend
+
+import (
+	"fmt"
+
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// closure stores the variables captured by a function literal
+type closure struct {
+	parent   *closure // enclosing function literal
+	captures []string // captured variables in order of their first use
+}
+
+// capture records the use of a variable of an enclosing function by the current function literal and all enclosing
+// literals which are nested inside the declaring function. Variables are captured by reference, the literal and the
+// enclosing function share the variable as long as the closure exists.
+func (parser *parser) capture(name string) {
+	literal := parser.closure
+	for count := parser.table.Captures(name); count > 0 && literal != nil; count-- {
+		captured := false
+		for _, capture := range literal.captures {
+			captured = captured || capture == name
+		}
+		if !captured {
+			literal.captures = append(literal.captures, name)
+		}
+		literal = literal.parent
+	}
+}
+
+// Closures returns the variables captured by each function literal in the order the literals appear in the file
+func (parser *parser) Closures() [][]string {
+	captures := make([][]string, len(parser.closures))
+	for i, literal := range parser.closures {
+		captures[i] = literal.captures
+	}
+	return captures
+}
+
+// parseFunctionType parses the type of a function value, the parameters are declared without names
+//
+// functionType
+//   : FUNC LBRACKET (parameterType (COMMA parameterType)*)? RBRACKET ARROW functionReturnType
+//   ;
+func (parser *parser) parseFunctionType() (language.IBasicType, error) {
+	if !parser.matchToken(tokens.FUNC) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.LBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '('")
+	}
+	var params []language.IBasicType
+	if !parser.lookAHead(tokens.RBRACKET) {
+		for {
+			paramType, err := parser.parseParameterType(parser)
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, paramType)
+			if !parser.lookAHead(tokens.COMMA) {
+				break
+			}
+			if !parser.matchToken(tokens.COMMA) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		}
+	}
+	if !parser.matchToken(tokens.RBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
+	}
+	if !parser.matchToken(tokens.ARROW) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '->'")
+	}
+	result, err := parser.parseFunctionReturnType(parser)
+	if err != nil {
+		return nil, err
+	}
+	return language.NewFunction(params, result), nil
+}
+
+// parseFunctionLiteral parses an anonymous function and returns its type. The body is parsed like the body of a
+// declared function, it returns to the caller of the literal and can not leave the loops and switches of the enclosing
+// function.
+//
+// functionLiteral
+//   : FUNC LBRACKET functionParameterDeclaration? RBRACKET ARROW functionReturnType scopeStatement
+//   ;
+func (parser *parser) parseFunctionLiteral(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.FUNC) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.LBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '('")
+	}
+	parser.table.NewFunctionScope("func")
+	var params []*parameter
+	if parser.lookAHeadParameter() {
+		var err error
+		if params, err = parserInterface.parseFunctionParamDeclaration(parserInterface); err != nil {
+			return nil, err
+		}
+	}
+	// function literals are only called through function values which do not know the parameter names
+	for _, param := range params {
+		if param.defaulted {
+			errMsg := fmt.Sprintf("Unexpected default value for parameter '%v' of function literal", param.name)
+			return nil, parser.newParserSyntaxError(invalidSyntax, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+		}
+	}
+	if !parser.matchToken(tokens.RBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected <terminal_variable_type> or ')'")
+	}
+	if !parser.matchToken(tokens.ARROW) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '->'")
+	}
+	returnType, err := parserInterface.parseFunctionReturnType(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	enclosingReturnType, enclosingFlow, enclosingTargets := parser.returnType, parser.flow, parser.targets
+	literal := &closure{parent: parser.closure}
+	parser.closures = append(parser.closures, literal)
+	parser.returnType, parser.targets, parser.closure = returnType, nil, literal
+	if err := parserInterface.parseScope(parserInterface); err != nil {
+		return nil, err
+	}
+	if parser.flow != flowReturn {
+		return nil, parser.controlFlowError(missingReturn, "Missing 'return' at '%v'")
+	}
+	parser.returnType, parser.flow, parser.targets, parser.closure = enclosingReturnType, enclosingFlow, enclosingTargets, literal.parent
+	parser.table.LeaveScope()
+	return language.NewFunction(paramTypes(params), returnType), nil
+}
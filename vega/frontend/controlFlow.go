@@ -0,0 +1,56 @@
+package frontend
+
+// controlFlow describes how the execution of a statement completes
+type controlFlow int
+
+const (
+	flowNormal controlFlow = iota // execution continues with the next statement
+	flowJump                      // break or continue leave the statement on every path
+	flowReturn                    // every path returns from the function or never ends
+)
+
+// jumpTarget is an enclosing loop or switch which can be left with break, continue is only allowed inside loops
+type jumpTarget struct {
+	loop   bool
+	broken bool // a break statement leaves the target
+}
+
+// statementSequence keeps track of the control flow of consecutive statements in a scope or switch case
+type statementSequence struct {
+	flow        controlFlow
+	unreachable bool // an unreachable statement has already been reported
+}
+
+// combineFlow returns how a branching statement completes when its branches complete as given, the statement only
+// returns when all branches return
+func combineFlow(branches ...controlFlow) controlFlow {
+	result := flowReturn
+	for _, flow := range branches {
+		if flow < result {
+			result = flow
+		}
+	}
+	return result
+}
+
+// enterJumpTarget is called by the parser before parsing the body of a loop or switch
+func (parser *parser) enterJumpTarget(loop bool) {
+	parser.targets = append(parser.targets, &jumpTarget{loop: loop})
+}
+
+// leaveJumpTarget is called by the parser after parsing the body of a loop or switch
+func (parser *parser) leaveJumpTarget() *jumpTarget {
+	target := parser.targets[len(parser.targets)-1]
+	parser.targets = parser.targets[:len(parser.targets)-1]
+	return target
+}
+
+// insideLoop checks if a continue statement has a target
+func (parser *parser) insideLoop() bool {
+	for _, target := range parser.targets {
+		if target.loop {
+			return true
+		}
+	}
+	return false
+}
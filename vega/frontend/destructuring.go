@@ -0,0 +1,211 @@
+package frontend
+
+import (
+	"govega/vega/frontend/utils"
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// blank is the identifier of variables whose value is discarded, it can be declared and assigned but not read
+const blank = "_"
+
+// target is a variable declared by a declaration with one or more names
+type target struct {
+	name        string
+	varType     language.IBasicType
+	declaration *topLevelDeclaration // top level declaration of a global variable
+}
+
+// parseDeclarationTargets parses the types and names of the declared variables, a name without type has the type of
+// the previous name. Global variables are added to the top level declarations of the package.
+//
+// declarationTargets
+//   : variableType ID (COMMA variableType? ID)*
+//   ;
+func (parser *parser) parseDeclarationTargets(parserInterface Parser, global bool, constant bool) ([]*target, error) {
+	varType, err := parserInterface.parseVariableType(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	var targets []*target
+	for {
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected <identifier> or '['")
+		}
+		declared := &target{name: parser.currentToken.GetToken().(tokens.IWord).GetLexeme(), varType: varType}
+		if global && declared.name != blank {
+			if declared.declaration, err = parser.declare(true, constant); err != nil {
+				return nil, err
+			}
+		}
+		targets = append(targets, declared)
+		if !parser.lookAHead(tokens.COMMA) {
+			return targets, nil
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		if parser.lookAHeadType() {
+			if varType, err = parserInterface.parseVariableType(parserInterface); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// addTargets adds the declared variables to the current scope, blank variables are discarded
+func (parser *parser) addTargets(targets []*target, constant bool) {
+	for _, declared := range targets {
+		if declared.name != blank {
+			parser.table.Add(utils.NewSymbol(declared.name, declared.varType, false, constant))
+		}
+	}
+}
+
+// targetTypes returns the types of the declared variables
+func targetTypes(targets []*target) []language.IBasicType {
+	types := make([]language.IBasicType, len(targets))
+	for i, declared := range targets {
+		types[i] = declared.varType
+	}
+	return types
+}
+
+// parseValues parses the comma separated values of a declaration, assignment or return statement
+//
+// values
+//   : booleanExpression (COMMA booleanExpression)*
+//   ;
+func (parser *parser) parseValues(parserInterface Parser) ([]language.IBasicType, error) {
+	var valueTypes []language.IBasicType
+	for {
+		valueType, err := parserInterface.parseBooleanExpression(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		valueTypes = append(valueTypes, valueType)
+		if !parser.lookAHead(tokens.COMMA) {
+			return valueTypes, nil
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	}
+}
+
+// destructure checks the values stored in the targets. The results of a single call are spread over several targets,
+// otherwise each target needs its own value. In declarations a single value initializes all declared variables.
+func (parser *parser) destructure(targets []language.IBasicType, values []language.IBasicType, declaration bool) error {
+	if len(values) == 1 && len(targets) > 1 {
+		if tuple, ok := values[0].(*language.TupleType); ok {
+			values = tuple.GetTypes()
+		} else if declaration {
+			for _, targetType := range targets {
+				if err := parser.storeError(targetType, values[0]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	if len(values) != len(targets) {
+		return parser.typeError("Wrong number of values in assignment, expected %v", len(targets))
+	}
+	for i, targetType := range targets {
+		if err := parser.storeError(targetType, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returnError checks the values of a return statement against the results of the function whose body is parsed. A
+// function with several results returns a tuple, which is no value of its own. The return statement lists one value per
+// result or returns the call of a function with the same results.
+func (parser *parser) returnError(values []language.IBasicType) error {
+	results := []language.IBasicType{parser.returnType}
+	// functions with multiple results can return the call of a function with the same results
+	if tuple, ok := parser.returnType.(*language.TupleType); ok && !(len(values) == 1 && assignable(tuple, values[0])) {
+		results = tuple.GetTypes()
+	}
+	if len(values) != len(results) {
+		return parser.typeError("Wrong number of return values, expected %v", len(results))
+	}
+	for i, result := range results {
+		if err := parser.storeError(result, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseDestructuringAssignment parses the assignment of several values, the leading targets have already been parsed.
+//
+// destructuringAssignment
+//   : (COMMA (ID (arrayAccess | fieldAccess)*))* ASSIGN values
+//   ;
+func (parser *parser) parseDestructuringAssignment(parserInterface Parser, targets []language.IBasicType) error {
+	for parser.lookAHead(tokens.COMMA) {
+		if !parser.matchToken(tokens.COMMA) {
+			return parser.syntaxError("lexicalError")
+		}
+		if !parser.matchToken(tokens.ID) {
+			return parser.syntaxError("Mismatched input '%v', expected <identifier>")
+		}
+		targetType, err := parser.parseAssignmentTarget(parserInterface)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, targetType)
+	}
+	if !parser.matchToken(tokens.ASSIGN) {
+		return parser.syntaxError("Mismatched input '%v', expected ',' or '='")
+	}
+	values, err := parser.parseValues(parserInterface)
+	if err != nil {
+		return err
+	}
+	return parser.destructure(targets, values, false)
+}
+
+// variableType returns the type of a variable which is assigned a value, the type of the blank identifier is unknown as
+// it accepts every value
+func (parser *parser) variableType(name string) (language.IBasicType, error) {
+	if name == blank {
+		return nil, nil
+	}
+	parser.reference(name)
+	parser.capture(name)
+	symbol, ok := parser.table.Lookup(name)
+	if !ok {
+		return nil, nil
+	}
+	if err := parser.constantError(name, symbol); err != nil {
+		return nil, err
+	}
+	return symbol.SymbolType, nil
+}
+
+// parseAssignmentTarget parses a variable, array element or field which is assigned a value, the leading identifier is
+// the current token. The type of the blank identifier is unknown as it accepts every value.
+func (parser *parser) parseAssignmentTarget(parserInterface Parser) (language.IBasicType, error) {
+	name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	if name == blank {
+		return nil, nil
+	}
+	parser.reference(name)
+	parser.capture(name)
+	symbol, _ := parser.table.Lookup(name)
+	targetType, called, err := parser.parseAccessOrCall(parserInterface, name, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if called {
+		_ = parser.matchToken(-1)
+		return nil, parser.syntaxError("Mismatched input '%v', expected '(', '[', '.', ',' or '='")
+	}
+	if err := parser.constantError(name, symbol); err != nil {
+		return nil, err
+	}
+	return targetType, nil
+}
@@ -0,0 +1,125 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// parseEnumDeclaration parses an enum declaration and registers the enum as new type. The variants can be spread over
+// several lines, the comma after the last variant can be omitted.
+//
+// enumDeclaration
+//   : ENUM ID LCURLY ID (COMMA ID)* COMMA? RCURLY
+//   ;
+func (parser *parser) parseEnumDeclaration() error {
+	if !parser.matchToken(tokens.ENUM) {
+		return parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	if _, ok := parser.types[name]; ok {
+		return parser.syntaxError("Redeclared type '%v'")
+	}
+	if !parser.matchToken(tokens.LCBRACKET) {
+		return parser.syntaxError("Mismatched input '%v', expected '{'")
+	}
+	var variants []string
+	for {
+		if !parser.matchToken(tokens.ID) {
+			return parser.syntaxError("Mismatched input '%v', expected <identifier>")
+		}
+		variant := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+		for _, declared := range variants {
+			if declared == variant {
+				return parser.syntaxError("Duplicate variant '%v'")
+			}
+		}
+		variants = append(variants, variant)
+		// a line break after a variant is converted into a delimiter by the lexer
+		if err := parser.parseLineBreak(); err != nil {
+			return err
+		}
+		if parser.lookAHead(tokens.RCBRACKET) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return parser.syntaxError("Mismatched input '%v', expected ',' or '}'")
+		}
+		if parser.lookAHead(tokens.RCBRACKET) {
+			break
+		}
+	}
+	if !parser.matchToken(tokens.RCBRACKET) {
+		return parser.syntaxError("lexicalError")
+	}
+	parser.types[name] = language.NewEnum(name, variants)
+	return nil
+}
+
+// lookAHeadEnum checks if the next token is the name of an enum
+func (parser *parser) lookAHeadEnum() bool {
+	if !parser.lookAHead(tokens.ID) {
+		return false
+	}
+	_, ok := parser.types[parser.nextToken.GetToken().(tokens.IWord).GetLexeme()].(*language.EnumType)
+	return ok
+}
+
+// parseVariant parses a variant qualified with the name of its enum, e.g. Color.Red, and returns the type of the enum
+//
+// variant
+//   : ID DOT ID
+//   ;
+func (parser *parser) parseVariant() (language.IBasicType, error) {
+	if !parser.matchToken(tokens.ID) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	enum := parser.types[parser.currentToken.GetToken().(tokens.IWord).GetLexeme()].(*language.EnumType)
+	if !parser.matchToken(tokens.DOT) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '.'")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	if _, ok := enum.GetVariant(parser.currentToken.GetToken().(tokens.IWord).GetLexeme()); !ok {
+		return nil, parser.moduleError(undefinedName, fmt.Sprintf("Undefined variant '%v.%%v'", enum.GetLexeme()))
+	}
+	return enum, nil
+}
+
+// parseCaseValue parses the value of a switch case, which is a terminal or a variant of an enum
+//
+// caseValue
+//   : terminal
+//   | variant
+//   ;
+func (parser *parser) parseCaseValue(parserInterface Parser) (language.IBasicType, error) {
+	if parser.lookAHeadEnum() {
+		return parser.parseVariant()
+	}
+	return parserInterface.parseTerminal()
+}
+
+// enumSwitchError returns a vega error on a switch without default case which does not handle all variants of the enum
+func (parser *parser) enumSwitchError(enum *language.EnumType, values map[string]bool, switchToken *lexicalToken) error {
+	var missing []string
+	for _, variant := range enum.GetVariants() {
+		if !values[variant] {
+			missing = append(missing, fmt.Sprintf("'%v.%v'", enum.GetLexeme(), variant))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	cases := "case"
+	if len(missing) > 1 {
+		cases = "cases"
+	}
+	errMsg := fmt.Sprintf("Missing %v %v in switch on '%v' without default", cases, strings.Join(missing, ", "), enum.GetLexeme())
+	return parser.newParserSyntaxError(nonExhaustiveSwitch, switchToken, errMsg, parser.lexer.getLineFeed())
+}
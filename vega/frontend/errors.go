@@ -11,12 +11,15 @@ type VErrorType string
 
 const (
 	syntaxError VErrorClass = "SyntaxError"
+	typeError   VErrorClass = "TypeError"
+	warning     VErrorClass = "Warning"
 )
 
 const (
 	malformedCode                    VErrorType = "MalformedCode"
 	unexpectedEOF                    VErrorType = "UnexpectedEOF"
 	literalNotTerminated             VErrorType = "LiteralNotTerminated"
+	unterminatedComment              VErrorType = "UnterminatedComment"
 	invalidCharacter                 VErrorType = "InvalidCharacter"
 	invalidEscapeSequence            VErrorType = "InvalidEscapeSequence"
 	invalidEscapeSequenceLiteral     VErrorType = "InvalidEscapeSequenceLiteral"
@@ -24,6 +27,20 @@ const (
 	invalidEscapeSequenceOctal       VErrorType = "InvalidEscapeSequenceOctal"
 	invalidEscapeSequenceUnicode     VErrorType = "InvalidEscapeSequenceUnicode"
 	invalidSyntax                    VErrorType = "InvalidSyntax"
+	typeMismatch                     VErrorType = "TypeMismatch"
+	invalidControlFlow               VErrorType = "InvalidControlFlow"
+	missingReturn                    VErrorType = "MissingReturn"
+	unreachableCode                  VErrorType = "UnreachableCode"
+	nonExhaustiveSwitch              VErrorType = "NonExhaustiveSwitch"
+	invalidImport                    VErrorType = "InvalidImport"
+	moduleNotFound                   VErrorType = "ModuleNotFound"
+	importCycle                      VErrorType = "ImportCycle"
+	undefinedName                    VErrorType = "UndefinedName"
+	initializationCycle              VErrorType = "InitializationCycle"
+	invalidInitializer               VErrorType = "InvalidInitializer"
+	indexOutOfBounds                 VErrorType = "IndexOutOfBounds"
+	escapingReference                VErrorType = "EscapingReference"
+	assignmentToConstant             VErrorType = "AssignmentToConstant"
 )
 
 type IVError interface {
@@ -128,6 +145,20 @@ func (v *vega) newParserSyntaxError(etype VErrorType, token *lexicalToken, msg s
 	return vErr
 }
 
+func (v *vega) newParserTypeError(etype VErrorType, token *lexicalToken, msg string, line string) IVError {
+	vErr := v.newParserSyntaxErrorObject(etype, token, msg, line)
+	vErr.class = typeError
+	var typeErr IVError = vErr
+	return typeErr
+}
+
+func (v *vega) newParserWarning(etype VErrorType, token *lexicalToken, msg string, line string) IVError {
+	vErr := v.newParserSyntaxErrorObject(etype, token, msg, line)
+	vErr.class = warning
+	var warningErr IVError = vErr
+	return warningErr
+}
+
 func (v *vParserError) String() string {
 	errString := fmt.Sprintf(`Error in: %v
 %v -> %v: at line %v position %v
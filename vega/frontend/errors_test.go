@@ -38,5 +38,5 @@ func TestVega_ErrorEmptyCode(t *testing.T) {
 		}
 	}()
 
-	vErr.Error()
+	_ = vErr.Error()
 }
@@ -0,0 +1,400 @@
+package frontend
+
+import (
+	"fmt"
+
+	"govega/vega/frontend/utils"
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// constraints of type parameters, a type parameter without constraint accepts every type, ordered accepts the types
+// which can be ordered (int, float, char and str) and numeric the types with arithmetic operations (int and float)
+const (
+	constraintAny     = ""
+	constraintOrdered = "ordered"
+	constraintNumeric = "numeric"
+)
+
+// typeParameter is the type of values whose type is a type parameter of the generic function whose body is parsed
+type typeParameter struct {
+	language.IBasicType
+	constraint string
+}
+
+// generic stores the calls of generic functions inside the body of a generic function whose type arguments depend on
+// its type parameters, they are instantiated together with the function
+type generic struct {
+	calls []genericCall
+}
+
+// genericCall is the call of a generic function with type arguments referring to type parameters
+type genericCall struct {
+	name     string
+	function *utils.Symbol
+	typeArgs []language.IBasicType
+}
+
+// generics stores the generic functions of a project and their instances
+type generics struct {
+	functions map[*utils.Symbol]*generic
+	instances []Instance
+}
+
+// Instance is a generic function instantiated with concrete type arguments. Every combination of type arguments a
+// function is called with is recorded, including the calls inside other generic functions, so the backends can generate
+// one copy of the function per instance and never see type parameters.
+type Instance struct {
+	Function string                // name of the function used by the call
+	TypeArgs []language.IBasicType // types of the type parameters in declaration order
+	Params   []language.IBasicType // parameter types of the instance
+	Result   language.IBasicType   // result type of the instance
+	symbol   *utils.Symbol
+}
+
+// String returns the name of the function with its type arguments, e.g. max[int]
+func (i Instance) String() string {
+	return fmt.Sprintf("%v[%v]", i.Function, typeNames(i.TypeArgs))
+}
+
+// Instances returns the instances of all generic functions of the project in the order they have been instantiated
+func (parser *parser) Instances() []Instance {
+	return parser.generics.instances
+}
+
+// parseTypeParameters parses the type parameters of a generic function and declares them as types of the function
+//
+// typeParameters
+//   : LARRAY ID (COLON ID)? (COMMA ID (COLON ID)?)* RARRAY
+//   ;
+func (parser *parser) parseTypeParameters() ([]language.IBasicType, error) {
+	if !parser.matchToken(tokens.LSBRACKET) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	var typeParams []language.IBasicType
+	for {
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+		}
+		name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+		if _, ok := parser.types[name]; ok {
+			return nil, parser.syntaxError("Redeclared type '%v'")
+		}
+		typeParam := &typeParameter{IBasicType: language.NewBasicType(name, tokens.TYPE, 0)}
+		if parser.lookAHead(tokens.COLON) {
+			if !parser.matchToken(tokens.COLON) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+			if !parser.matchToken(tokens.ID) {
+				return nil, parser.syntaxError("Mismatched input '%v', expected 'ordered' or 'numeric'")
+			}
+			typeParam.constraint = parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+			if typeParam.constraint != constraintOrdered && typeParam.constraint != constraintNumeric {
+				return nil, parser.syntaxError("Unknown constraint '%v', expected 'ordered' or 'numeric'")
+			}
+		}
+		parser.types[name] = typeParam
+		typeParams = append(typeParams, typeParam)
+		if !parser.lookAHead(tokens.COMMA) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	}
+	if !parser.matchToken(tokens.RSBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected ',' or ']'")
+	}
+	return typeParams, nil
+}
+
+// usedBeforeDeclaration returns a vega error if a generic function is used by a declaration in front of it, the name
+// of the function is the current token. The instances of such a call would be unknown.
+func (parser *parser) usedBeforeDeclaration(name string) error {
+	for _, declaration := range parser.globals.declarations {
+		for _, reference := range declaration.references {
+			if reference == name && declaration.name != name {
+				return parser.syntaxError("Generic function '%v' is used before its declaration")
+			}
+		}
+	}
+	return nil
+}
+
+// unusedTypeParameter returns a vega error if a type parameter cannot be inferred because no parameter refers to it
+func (parser *parser) unusedTypeParameter(name string, function *utils.Symbol) error {
+	for _, typeParam := range function.TypeParams {
+		used := false
+		for _, param := range function.Params {
+			used = used || refersTo(param, typeParam.(*typeParameter))
+		}
+		if !used {
+			return parser.typeError("Type parameter '%v' of '%v' is not used by the parameters", typeParam.GetLexeme(), name)
+		}
+	}
+	return nil
+}
+
+// refersTo checks if a type contains the type parameter, a nil type parameter matches every type parameter
+func refersTo(t language.IBasicType, typeParam *typeParameter) bool {
+	switch v := t.(type) {
+	case *typeParameter:
+		return typeParam == nil || v == typeParam
+	case *language.SliceType:
+		return refersTo(v.GetElementType(), typeParam)
+	case *language.PointerType:
+		return refersTo(v.GetElementType(), typeParam)
+	case *language.ResultType:
+		return refersTo(v.GetValueType(), typeParam)
+	case *language.FunctionType:
+		for _, param := range v.GetParams() {
+			if refersTo(param, typeParam) {
+				return true
+			}
+		}
+		return refersTo(v.GetResult(), typeParam)
+	case *language.TupleType:
+		for _, element := range v.GetTypes() {
+			if refersTo(element, typeParam) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// substitute replaces the type parameters of a type by their type arguments
+func substitute(t language.IBasicType, typeArgs map[*typeParameter]language.IBasicType) language.IBasicType {
+	switch v := t.(type) {
+	case *typeParameter:
+		if typeArg, ok := typeArgs[v]; ok {
+			return typeArg
+		}
+		return v
+	case *language.SliceType:
+		return language.NewSlice(substitute(v.GetElementType(), typeArgs))
+	case *language.PointerType:
+		if v == nilPointer {
+			return v
+		}
+		return language.NewPointer(substitute(v.GetElementType(), typeArgs))
+	case *language.ResultType:
+		if v == failure {
+			return v
+		}
+		return language.NewResult(substitute(v.GetValueType(), typeArgs))
+	case *language.FunctionType:
+		params := make([]language.IBasicType, len(v.GetParams()))
+		for i, param := range v.GetParams() {
+			params[i] = substitute(param, typeArgs)
+		}
+		return language.NewFunction(params, substitute(v.GetResult(), typeArgs))
+	case *language.TupleType:
+		elements := make([]language.IBasicType, len(v.GetTypes()))
+		for i, element := range v.GetTypes() {
+			elements[i] = substitute(element, typeArgs)
+		}
+		return language.NewTuple(elements)
+	default:
+		return t
+	}
+}
+
+// satisfies checks if a type argument fulfills the constraint of a type parameter. A type parameter of the enclosing
+// function satisfies the constraints which are implied by its own constraint.
+func satisfies(t language.IBasicType, constraint string) bool {
+	if typeParam, ok := t.(*typeParameter); ok {
+		return constraint == constraintAny || typeParam.constraint == constraint ||
+			(constraint == constraintOrdered && typeParam.constraint == constraintNumeric)
+	}
+	switch constraint {
+	case constraintNumeric:
+		return numeric(t)
+	case constraintOrdered:
+		_, str := t.(*language.StringType)
+		return numeric(t) || t == language.CharType || str
+	default:
+		return true
+	}
+}
+
+// orderable checks if the operands of an ordering comparison can be ordered, values of type parameters need a
+// constraint which only accepts ordered types and pointers cannot be ordered
+func orderable(t language.IBasicType) bool {
+	switch v := t.(type) {
+	case *typeParameter:
+		return v.constraint != constraintAny
+	case *language.PointerType:
+		return false
+	}
+	return true
+}
+
+// unify infers the type arguments of a call from the type of an argument and the type of its parameter. The type
+// argument of a type parameter used by several arguments is their common type, integers are promoted to floating
+// point numbers.
+func (parser *parser) unify(name string, param language.IBasicType, arg language.IBasicType, typeArgs map[*typeParameter]language.IBasicType) error {
+	if arg == nil {
+		return nil
+	}
+	switch p := param.(type) {
+	case *typeParameter:
+		typeArg, ok := typeArgs[p]
+		switch {
+		case !ok:
+			typeArgs[p] = arg
+		case assignable(typeArg, arg):
+		case assignable(arg, typeArg):
+			typeArgs[p] = arg
+		default:
+			return parser.typeError("Mismatched type '%v' for type parameter '%v' in call of '%v', expected '%v'", arg, p.GetLexeme(), name, typeArg)
+		}
+	case *language.SliceType:
+		switch a := arg.(type) {
+		case *language.SliceType:
+			return parser.unify(name, p.GetElementType(), a.GetElementType(), typeArgs)
+		case *language.ArrayType:
+			return parser.unify(name, p.GetElementType(), elementType(a), typeArgs)
+		}
+	case *language.PointerType:
+		if a, ok := arg.(*language.PointerType); ok && a != nilPointer {
+			return parser.unify(name, p.GetElementType(), a.GetElementType(), typeArgs)
+		}
+	case *language.ResultType:
+		if a, ok := arg.(*language.ResultType); ok {
+			return parser.unify(name, p.GetValueType(), a.GetValueType(), typeArgs)
+		}
+	case *language.FunctionType:
+		if a, ok := arg.(*language.FunctionType); ok && len(a.GetParams()) == len(p.GetParams()) {
+			for i, element := range p.GetParams() {
+				if err := parser.unify(name, element, a.GetParams()[i], typeArgs); err != nil {
+					return err
+				}
+			}
+			return parser.unify(name, p.GetResult(), a.GetResult(), typeArgs)
+		}
+	}
+	return nil
+}
+
+// instantiate infers the type arguments of a direct call of a generic function from the arguments bound to its
+// parameters and returns the parameter and result types of the instance. The types remain unknown if a type argument
+// cannot be inferred, e.g. because the type of an argument is unknown.
+func (parser *parser) instantiate(name string, function *utils.Symbol, args []language.IBasicType) ([]language.IBasicType, language.IBasicType, error) {
+	typeArgs := map[*typeParameter]language.IBasicType{}
+	for i, param := range function.Params {
+		if err := parser.unify(name, param, args[i], typeArgs); err != nil {
+			return nil, nil, err
+		}
+	}
+	inferred := make([]language.IBasicType, len(function.TypeParams))
+	for i, t := range function.TypeParams {
+		typeParam := t.(*typeParameter)
+		typeArg, ok := typeArgs[typeParam]
+		if !ok {
+			return nil, nil, nil
+		}
+		if !satisfies(typeArg, typeParam.constraint) {
+			return nil, nil, parser.typeError("Type '%v' does not satisfy '%v' of type parameter '%v' in call of '%v'", typeArg, typeParam.constraint, typeParam.GetLexeme(), name)
+		}
+		inferred[i] = typeArg
+	}
+	params := make([]language.IBasicType, len(function.Params))
+	for i, param := range function.Params {
+		params[i] = substitute(param, typeArgs)
+	}
+	// calls inside generic functions are instantiated when the calling function is instantiated
+	dependent := false
+	for _, typeArg := range inferred {
+		dependent = dependent || refersTo(typeArg, nil)
+	}
+	if dependent && parser.generic != nil {
+		parser.generic.calls = append(parser.generic.calls, genericCall{name: name, function: function, typeArgs: inferred})
+	} else if !dependent {
+		if growing := parser.generics.instantiate(name, function, inferred, nil); growing != nil {
+			return nil, nil, parser.typeError("Recursive instantiation of '%v' with growing type arguments '%v'", growing.Function, typeNames(growing.TypeArgs))
+		}
+	}
+	return params, substitute(function.SymbolType, typeArgs), nil
+}
+
+// instantiate records the instance of a generic function with concrete type arguments and instantiates the generic
+// functions called by it, the chain holds the instances whose calls are instantiated. A function which calls itself
+// with type arguments built from its own type arguments would need infinitely many instances, the first instance with
+// grown type arguments is returned.
+func (g *generics) instantiate(name string, function *utils.Symbol, typeArgs []language.IBasicType, chain []Instance) *Instance {
+	instance := Instance{Function: name, TypeArgs: typeArgs, symbol: function}
+	for _, enclosing := range chain {
+		if enclosing.symbol == function && grown(enclosing.TypeArgs, typeArgs) {
+			return &instance
+		}
+	}
+	for _, existing := range g.instances {
+		if existing.symbol == function && typeNames(existing.TypeArgs) == typeNames(typeArgs) {
+			return nil
+		}
+	}
+	bindings := map[*typeParameter]language.IBasicType{}
+	for i, typeParam := range function.TypeParams {
+		bindings[typeParam.(*typeParameter)] = typeArgs[i]
+	}
+	for _, param := range function.Params {
+		instance.Params = append(instance.Params, substitute(param, bindings))
+	}
+	instance.Result = substitute(function.SymbolType, bindings)
+	g.instances = append(g.instances, instance)
+	if declared, ok := g.functions[function]; ok {
+		for _, call := range declared.calls {
+			callTypeArgs := make([]language.IBasicType, len(call.typeArgs))
+			for i, typeArg := range call.typeArgs {
+				callTypeArgs[i] = substitute(typeArg, bindings)
+			}
+			if growing := g.instantiate(call.name, call.function, callTypeArgs, append(chain, instance)); growing != nil {
+				return growing
+			}
+		}
+	}
+	return nil
+}
+
+// grown checks if one of the type arguments is built from the previous type argument of the same type parameter, e.g.
+// int[] is built from int
+func grown(previous []language.IBasicType, typeArgs []language.IBasicType) bool {
+	for i, typeArg := range typeArgs {
+		if typeName(typeArg) != typeName(previous[i]) && containsType(typeArg, previous[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsType checks if a type is the given part or is composed of it
+func containsType(t language.IBasicType, part language.IBasicType) bool {
+	if typeName(t) == typeName(part) {
+		return true
+	}
+	switch v := t.(type) {
+	case *language.ArrayType:
+		return containsType(elementType(v), part)
+	case *language.SliceType:
+		return containsType(v.GetElementType(), part)
+	case *language.PointerType:
+		return v != nilPointer && containsType(v.GetElementType(), part)
+	case *language.ResultType:
+		return v != failure && containsType(v.GetValueType(), part)
+	case *language.FunctionType:
+		for _, param := range v.GetParams() {
+			if containsType(param, part) {
+				return true
+			}
+		}
+		return containsType(v.GetResult(), part)
+	case *language.TupleType:
+		for _, element := range v.GetTypes() {
+			if containsType(element, part) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package frontend
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// TextEdit describes the replacement of the code between the byte offsets Start (inclusive) and End (exclusive)
+type TextEdit struct {
+	Start       int
+	End         int
+	Replacement []byte
+}
+
+// lexerState stores everything the lexer needs to continue scanning at an offset of the code
+type lexerState struct {
+	offset         int
+	line           int
+	lastTag        int
+	docComment     string
+	interpolations []int
+	brackets       []int
+}
+
+// equals checks if two states lead to the same tokens when scanning the same code
+func (s lexerState) equals(o lexerState) bool {
+	if s.line != o.line || s.lastTag != o.lastTag || s.docComment != o.docComment {
+		return false
+	}
+	if len(s.interpolations) != len(o.interpolations) || len(s.brackets) != len(o.brackets) {
+		return false
+	}
+	for i := range s.interpolations {
+		if s.interpolations[i] != o.interpolations[i] {
+			return false
+		}
+	}
+	for i := range s.brackets {
+		if s.brackets[i] != o.brackets[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TokenList holds the tokens of a source code together with the lexer state in front of each token
+type TokenList struct {
+	code    []byte
+	tokens  []*lexicalToken
+	states  []lexerState
+	scanned int // number of tokens which have been scanned, the others were taken from the previous list
+}
+
+// GetCode getter method to retrieve the tokenized code
+func (t *TokenList) GetCode() []byte {
+	return t.code
+}
+
+// GetTokens getter method to retrieve all tokens including the final EOF token
+func (t *TokenList) GetTokens() []*lexicalToken {
+	return t.tokens
+}
+
+// end returns the offset behind the token at index i, the next character has been read by the lexer as well
+func (t *TokenList) end(i int) int {
+	if i+1 < len(t.states) {
+		return t.states[i+1].offset
+	}
+	return len(t.code)
+}
+
+// Tokenize scans the complete code and returns all tokens
+func (v *vega) Tokenize(code []byte) (*TokenList, error) {
+	list := &TokenList{code: code}
+	l, err := v.restartLexer(code, lexerState{line: 1})
+	if err != nil {
+		return nil, err
+	}
+	if err = l.scanTokens(list, nil); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Retokenize applies the edit to the code of the previous token list and scans only the affected tokens again. The
+// lexer is restarted in front of the first token which could be affected and scans until it reaches an old token behind
+// the edit with the same lexer state. From there on the old tokens are reused and only their locations are moved.
+func (v *vega) Retokenize(previous *TokenList, edit TextEdit) (*TokenList, error) {
+	if edit.Start < 0 || edit.Start > edit.End || edit.End > len(previous.code) {
+		return nil, errors.New("text edit out of range")
+	}
+	code := make([]byte, 0, len(previous.code)-(edit.End-edit.Start)+len(edit.Replacement))
+	code = append(code, previous.code[:edit.Start]...)
+	code = append(code, edit.Replacement...)
+	code = append(code, previous.code[edit.End:]...)
+
+	// the lexer reads one character ahead, therefore a token ending directly at the edit has to be scanned again
+	restart := sort.Search(len(previous.tokens), func(i int) bool {
+		return previous.end(i) >= edit.Start
+	})
+	list := &TokenList{
+		code:   code,
+		tokens: append([]*lexicalToken(nil), previous.tokens[:restart]...),
+		states: append([]lexerState(nil), previous.states[:restart]...),
+	}
+	l, err := v.restartLexer(code, previous.states[restart])
+	if err != nil {
+		return nil, err
+	}
+
+	editEnd := edit.Start + len(edit.Replacement)
+	offsetDelta := editEnd - edit.End
+	lineDelta := bytes.Count(edit.Replacement, []byte{'\n'}) - bytes.Count(previous.code[edit.Start:edit.End], []byte{'\n'})
+	// only tokens on the line where the edit ends are moved horizontally
+	editLine := bytes.Count(previous.code[:edit.End], []byte{'\n'}) + 1
+	oldLineStart := bytes.LastIndexByte(previous.code[:edit.End], '\n') + 1
+	newLineStart := bytes.LastIndexByte(code[:editEnd], '\n') + 1
+	positionDelta := utf8.RuneCount(code[newLineStart:editEnd]) - utf8.RuneCount(previous.code[oldLineStart:edit.End])
+
+	resync := func(state lexerState) bool {
+		if state.offset < editEnd {
+			return false
+		}
+		i := sort.Search(len(previous.states), func(i int) bool {
+			return previous.states[i].offset >= state.offset-offsetDelta
+		})
+		if i == len(previous.states) || previous.states[i].offset != state.offset-offsetDelta {
+			return false
+		}
+		old := previous.states[i]
+		old.line += lineDelta
+		if !state.equals(old) {
+			return false
+		}
+		for ; i < len(previous.tokens); i++ {
+			token := *previous.tokens[i]
+			if token.line == editLine {
+				token.position += positionDelta
+			}
+			token.line += lineDelta
+			state := previous.states[i]
+			state.offset += offsetDelta
+			state.line += lineDelta
+			list.tokens = append(list.tokens, &token)
+			list.states = append(list.states, state)
+		}
+		return true
+	}
+	if err = l.scanTokens(list, resync); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// restartLexer creates a lexer which continues scanning the code at the given state
+func (v *vega) restartLexer(code []byte, state lexerState) (*lexer, error) {
+	lineStart := bytes.LastIndexByte(code[:state.offset], '\n') + 1
+	// error messages show the code line, so all previous lines have to be known
+	codeLines := strings.SplitAfter(string(code[:lineStart]), "\n")
+	l := &lexer{
+		vega:           &vega{file: v.file, modules: v.modules, codeLines: codeLines[:len(codeLines)-1]},
+		code:           bytes.NewReader(code),
+		words:          language.KeyWords,
+		lineFeed:       string(code[lineStart:state.offset]),
+		line:           state.line,
+		position:       utf8.RuneCount(code[lineStart:state.offset]),
+		docComment:     state.docComment,
+		interpolations: append([]int(nil), state.interpolations...),
+		brackets:       append([]int(nil), state.brackets...),
+		lastTag:        state.lastTag,
+		version:        v.languageVersion(),
+	}
+	if _, err := l.code.Seek(int64(state.offset), io.SeekStart); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// getState private method to take a snapshot of the lexer state in front of the next token
+func (l *lexer) getState() lexerState {
+	return lexerState{
+		offset:         int(l.code.Size()) - l.code.Len(),
+		line:           l.line,
+		lastTag:        l.lastTag,
+		docComment:     l.docComment,
+		interpolations: append([]int(nil), l.interpolations...),
+		brackets:       append([]int(nil), l.brackets...),
+	}
+}
+
+// scanTokens private method to append tokens to the list until EOF or until resync reports that the remaining tokens
+// have been taken from a previous list
+func (l *lexer) scanTokens(list *TokenList, resync func(state lexerState) bool) error {
+	for {
+		state := l.getState()
+		if resync != nil && resync(state) {
+			return nil
+		}
+		token, err := l.scan()
+		if err != nil {
+			return err
+		}
+		list.tokens = append(list.tokens, token)
+		list.states = append(list.states, state)
+		list.scanned++
+		if token.GetTag() == tokens.EOF {
+			return nil
+		}
+	}
+}
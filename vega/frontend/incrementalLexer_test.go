@@ -0,0 +1,116 @@
+package frontend
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// fragments inserted by the random edits, chosen to change comments, literals, brackets and statement endings
+var editFragments = []string{
+	"", " ", "\n", "a", "x1", "func", "return", "42", "1.5", "(", ")", "[", "]", "{", "}", "=", "==", "!", "/", "//",
+	"///", "/*", "*/", "\"", "'", "`", "${", "\\", "\\n", "\\u{1F600}", ";", "+", "-", "*", "\"a ${b} c\"",
+}
+
+var editSnippets = []string{
+	"/// doc\nfunc f() int {\n\tstr s = \"x ${a + \"${b}\"} y\"\n\treturn `raw\nline`\n}\n",
+	"func main() int {\n\t/* outer /* nested */ still\n comment */ int a = (1 +\n 2)\n\tif a { a = 'c' }\n}",
+}
+
+// tokenDump formats all properties of the tokens which have to be equal after an incremental re-tokenization
+func tokenDump(list *TokenList) []string {
+	dump := make([]string, len(list.tokens))
+	for i, token := range list.tokens {
+		dump[i] = fmt.Sprintf("%d %q %d:%d %q %+v", token.GetTag(), token.GetToken().String(), token.line, token.position,
+			token.doc, list.states[i])
+	}
+	return dump
+}
+
+func loadEditCodes(t *testing.T) [][]byte {
+	files, err := filepath.Glob("../../resources/specs/*.vg")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no spec files found: %v", err)
+	}
+	var codes [][]byte
+	for _, file := range files {
+		code, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		codes = append(codes, code)
+	}
+	for _, snippet := range editSnippets {
+		codes = append(codes, []byte(snippet))
+	}
+	return codes
+}
+
+func randomEdit(r *rand.Rand, code []byte) TextEdit {
+	start := r.Intn(len(code) + 1)
+	end := start + r.Intn(4)
+	if end > len(code) {
+		end = len(code)
+	}
+	return TextEdit{Start: start, End: end, Replacement: []byte(editFragments[r.Intn(len(editFragments))])}
+}
+
+func TestVega_Retokenize(t *testing.T) {
+	v := NewVega("/path/to/test.vg")
+	r := rand.New(rand.NewSource(1))
+	for _, code := range loadEditCodes(t) {
+		previous, err := v.Tokenize(code)
+		if err != nil {
+			t.Fatalf("Tokenize() error = %v", err)
+		}
+		for i := 0; i < 500; i++ {
+			edit := randomEdit(r, previous.code)
+			got, gotErr := v.Retokenize(previous, edit)
+			want, wantErr := v.Tokenize(applyEdit(previous.code, edit))
+			if (gotErr == nil) != (wantErr == nil) || (gotErr != nil && gotErr.Error() != wantErr.Error()) {
+				t.Fatalf("Retokenize(%q, %+v) error = %v, want %v", previous.code, edit, gotErr, wantErr)
+			}
+			if wantErr != nil {
+				continue
+			}
+			if !reflect.DeepEqual(tokenDump(got), tokenDump(want)) {
+				t.Fatalf("Retokenize(%q, %+v) = %v, want %v", previous.code, edit, tokenDump(got), tokenDump(want))
+			}
+			previous = got
+		}
+	}
+}
+
+func applyEdit(code []byte, edit TextEdit) []byte {
+	edited := append([]byte(nil), code[:edit.Start]...)
+	edited = append(edited, edit.Replacement...)
+	return append(edited, code[edit.End:]...)
+}
+
+func TestVega_RetokenizeScansAffectedTokensOnly(t *testing.T) {
+	v := NewVega("/path/to/test.vg")
+	code := []byte("func main() int {\n\tint a = 1\n\tint b = 2\n\tint c = 3\n\treturn a\n}\n")
+	previous, err := v.Tokenize(code)
+	if err != nil {
+		t.Fatalf("Tokenize() error = %v", err)
+	}
+	start := len("func main() int {\n\tint ")
+	got, err := v.Retokenize(previous, TextEdit{Start: start, End: start + 1, Replacement: []byte("value")})
+	if err != nil {
+		t.Fatalf("Retokenize() error = %v", err)
+	}
+	if got.scanned != 1 {
+		t.Errorf("Retokenize() scanned %v tokens, want 1", got.scanned)
+	}
+	if token := got.tokens[len(got.tokens)-3]; token.line != 6 || token.position != 0 {
+		t.Errorf("Retokenize() moved closing bracket to %v:%v, want 6:0", token.line, token.position)
+	}
+
+	_, err = v.Retokenize(previous, TextEdit{Start: 5, End: 4})
+	if err == nil {
+		t.Errorf("Retokenize() accepted an invalid edit")
+	}
+}
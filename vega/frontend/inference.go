@@ -0,0 +1,96 @@
+package frontend
+
+import (
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// lookAHeadInferredDeclaration checks if the next token starts a declaration without type
+func (parser *parser) lookAHeadInferredDeclaration() bool {
+	return parser.lookAHead(tokens.VAR) || parser.lookAHead(tokens.LET)
+}
+
+// parseInferredDeclaration parses the declaration of variables or constants with inferred types and adds them to the
+// current scope
+//
+// inferredDeclaration
+//   : (VAR | LET) ID (COMMA ID)* ASSIGN inferredValue (COMMA inferredValue)*
+//   ;
+func (parser *parser) parseInferredDeclaration(parserInterface Parser) error {
+	constant := parser.lookAHead(tokens.LET)
+	keyword := tokens.VAR
+	if constant {
+		keyword = tokens.LET
+	}
+	if !parser.matchToken(keyword) {
+		return parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	targets, err := parser.parseNames(false)
+	if err != nil {
+		return err
+	}
+	if !parser.matchToken(tokens.ASSIGN) {
+		return parser.syntaxError("Mismatched input '%v', expected ',' or '='")
+	}
+	var values []language.IBasicType
+	for {
+		valueType, err := parser.parseInferredValue(parserInterface)
+		if err != nil {
+			return err
+		}
+		values = append(values, valueType)
+		if !parser.lookAHead(tokens.COMMA) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return parser.syntaxError("lexicalError")
+		}
+	}
+	// the results of a single call are spread over the names, otherwise a single value initializes all names
+	if len(values) == 1 && len(targets) > 1 {
+		if tuple, ok := values[0].(*language.TupleType); ok {
+			values = tuple.GetTypes()
+		} else {
+			for range targets[1:] {
+				values = append(values, values[0])
+			}
+		}
+	}
+	if len(values) != len(targets) {
+		return parser.typeError("Wrong number of values in assignment, expected %v", len(targets))
+	}
+	for i, declared := range targets {
+		if _, ok := values[i].(*language.TupleType); ok {
+			return parser.typeError("Multiple values '%v' in single-value context", values[i])
+		}
+		declared.varType = values[i]
+	}
+	parser.addTargets(targets, constant)
+	parser.declareReferences(targets)
+	return nil
+}
+
+// parseInferredValue parses the value of a declaration without type and returns the type of the declared name. Array
+// literals are arrays with the type and the number of their elements, values without a known type, like an empty array
+// literal, need a declaration with type.
+//
+// inferredValue
+//   : booleanExpression
+//   ;
+func (parser *parser) parseInferredValue(parserInterface Parser) (language.IBasicType, error) {
+	empty := parser.lookAHead(tokens.LSBRACKET) && parser.peekAHead(tokens.RSBRACKET)
+	valueType, err := parserInterface.parseBooleanExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if empty && valueType == nil {
+		return nil, parser.typeError("Cannot infer type of empty array literal, declare the type of the array")
+	}
+	if valueType == nilPointer {
+		return nil, parser.typeError("Cannot infer type of nil, declare the type of the pointer")
+	}
+	return valueType, nil
+}
@@ -0,0 +1,175 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+)
+
+// topLevelDeclaration is a global variable, constant or function of a package
+type topLevelDeclaration struct {
+	*vega      // file of the declaration for error messages
+	name       string
+	token      *lexicalToken // identifier of the declaration
+	lineFeed   string
+	variable   bool     // global variable or constant, otherwise a function
+	constant   bool     // global declared with const
+	dynamic    bool     // the initializer uses a value of an imported module which is not constant
+	local      bool     // the value uses local variables, e.g. the default value of a parameter
+	references []string // top level names used by the initializer or the function body
+}
+
+// initialization stores the top level declarations of a package in declaration order
+type initialization struct {
+	declarations []*topLevelDeclaration
+	order        []string // global variables in initialization order
+}
+
+// lookup searches the top level declaration with the given name
+func (i *initialization) lookup(name string) *topLevelDeclaration {
+	for _, declaration := range i.declarations {
+		if declaration.name == name {
+			return declaration
+		}
+	}
+	return nil
+}
+
+// dependencies returns the global variables which have to be initialized before the declaration, which are the global
+// variables its initializer uses directly or through the functions it calls
+func (i *initialization) dependencies(declaration *topLevelDeclaration) []*topLevelDeclaration {
+	var variables []*topLevelDeclaration
+	visited := map[*topLevelDeclaration]bool{declaration: true}
+	var visit func(d *topLevelDeclaration)
+	visit = func(d *topLevelDeclaration) {
+		for _, name := range d.references {
+			reference := i.lookup(name)
+			if reference == nil || visited[reference] {
+				continue
+			}
+			visited[reference] = true
+			if reference.variable {
+				variables = append(variables, reference)
+			} else {
+				visit(reference)
+			}
+		}
+	}
+	visit(declaration)
+	return variables
+}
+
+// cycle returns the chain of declarations leading from the declaration back to itself
+func (i *initialization) cycle(declaration *topLevelDeclaration) []string {
+	var chain []string
+	visited := map[*topLevelDeclaration]bool{}
+	var visit func(d *topLevelDeclaration) bool
+	visit = func(d *topLevelDeclaration) bool {
+		chain = append(chain, d.name)
+		for _, name := range d.references {
+			reference := i.lookup(name)
+			if reference == declaration {
+				chain = append(chain, reference.name)
+				return true
+			}
+			if reference == nil || visited[reference] {
+				continue
+			}
+			visited[reference] = true
+			if visit(reference) {
+				return true
+			}
+		}
+		chain = chain[:len(chain)-1]
+		return false
+	}
+	if visit(declaration) {
+		return chain
+	}
+	return nil
+}
+
+// constantInitializer checks if the initializer of a global only uses literals and constants
+func (i *initialization) constantInitializer(declaration *topLevelDeclaration) bool {
+	if declaration.dynamic || declaration.local {
+		return false
+	}
+	for _, name := range declaration.references {
+		if reference := i.lookup(name); reference != nil && !reference.constant {
+			return false
+		}
+	}
+	return true
+}
+
+// analyze validates the initializers of all global variables and computes the initialization order. The variables are
+// initialized in declaration order unless they depend on a variable which is declared later, in this case the later
+// variable is initialized first. A variable which depends on itself can never be initialized.
+func (i *initialization) analyze(staticGlobals bool) error {
+	var variables []*topLevelDeclaration
+	for _, declaration := range i.declarations {
+		if !declaration.variable {
+			continue
+		}
+		if chain := i.cycle(declaration); chain != nil {
+			errMsg := fmt.Sprintf("Initialization cycle: %v", strings.Join(chain, " -> "))
+			return declaration.newParserSyntaxError(initializationCycle, declaration.token, errMsg, declaration.lineFeed)
+		}
+		if (declaration.constant || staticGlobals) && !i.constantInitializer(declaration) {
+			errMsg := fmt.Sprintf("Non-constant initializer for global '%v'", declaration.name)
+			return declaration.newParserSyntaxError(invalidInitializer, declaration.token, errMsg, declaration.lineFeed)
+		}
+		variables = append(variables, declaration)
+	}
+	initialized := map[*topLevelDeclaration]bool{}
+	i.order = nil
+	for len(i.order) < len(variables) {
+		// the first variable in declaration order whose dependencies are initialized is initialized next
+		for _, variable := range variables {
+			if initialized[variable] {
+				continue
+			}
+			ready := true
+			for _, dependency := range i.dependencies(variable) {
+				ready = ready && initialized[dependency]
+			}
+			if ready {
+				initialized[variable] = true
+				i.order = append(i.order, variable.name)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// declare adds a top level declaration of the parsed file, the names of all top level declarations of a package have
+// to be unique
+func (parser *parser) declare(variable bool, constant bool) (*topLevelDeclaration, error) {
+	name := parser.currentToken.GetToken().String()
+	if parser.globals.lookup(name) != nil {
+		return nil, parser.syntaxError("Redeclared '%v'")
+	}
+	declaration := &topLevelDeclaration{
+		vega:     parser.vega,
+		name:     name,
+		token:    parser.currentToken,
+		lineFeed: parser.lexer.getLineFeed(),
+		variable: variable,
+		constant: constant,
+	}
+	parser.globals.declarations = append(parser.globals.declarations, declaration)
+	return declaration, nil
+}
+
+// reference records the use of an identifier by the current top level declaration unless a local symbol with the
+// same name is visible
+func (parser *parser) reference(name string) {
+	if parser.declaration == nil {
+		return
+	}
+	if _, ok := parser.table.Lookup(name); ok && !parser.table.IsGlobal(name) {
+		parser.declaration.local = true
+		return
+	}
+	parser.declaration.references = append(parser.declaration.references, name)
+}
@@ -1,31 +1,59 @@
 package frontend
 
-import "govega/vega/language/tokens"
+import (
+	"govega/vega/frontend/utils"
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
 
 type Vega interface {
 	NewLexer(code []byte) Lexer
 	NewParser(lexer Lexer) Parser
+	Tokenize(code []byte) (*TokenList, error)
+	Retokenize(previous *TokenList, edit TextEdit) (*TokenList, error)
+	RequireStaticGlobals()
+	SetLanguageVersion(version LanguageVersion)
 }
 
 // Parser interface which allows better testing capacities
 type Parser interface {
 	Parse(p Parser) error
+	Lookup(name string) (*utils.Symbol, bool)
+	Warnings() []IVError
+	InitializationOrder() []string
+	Closures() [][]string
+	Instances() []Instance
 	parseBlock(p Parser) error
-	parseFunctionParamDeclaration(p Parser) error
-	parseFunctionParamDefinition(p Parser) error
-	parseFunctionReturnType(p Parser) error
-	parseArrayAccess(p Parser) error
-	parseTerminalVariableType() error
+	parseImportDeclaration(p Parser) error
+	parseStructDeclaration(p Parser) error
+	parseGlobalDeclaration(p Parser) error
+	parseFunctionParamDeclaration(p Parser) ([]*parameter, error)
+	parseFunctionParamDefinition(p Parser) (*parameter, error)
+	parseFunctionReturnType(p Parser) (language.IBasicType, error)
+	parseArrayAccess(p Parser, arrayType language.IBasicType) (language.IBasicType, error)
+	parseFieldAccess(p Parser, structType language.IBasicType) (language.IBasicType, error)
+	parseVariableType(p Parser) (language.IBasicType, error)
+	parseTerminalVariableType() (language.IBasicType, error)
 	parseScope(p Parser) error
 	parseStatement(p Parser) error
+	parseDeclaration(p Parser) error
+	parseAssignmentOrCall(p Parser) error
+	parseForStatement(p Parser) error
+	parseSwitchStatement(p Parser) error
 	parseConditionalScope(p Parser) error
-	parseBooleanExpression(p Parser) error
-	parseComparisonExpression(p Parser) error
-	parseExpression(p Parser) error
-	parseTerm(p Parser) error
-	parseFactor(p Parser) error
-	parseUnary(p Parser) error
-	parseTerminal() error
+	parseBooleanExpression(p Parser) (language.IBasicType, error)
+	parseAndExpression(p Parser) (language.IBasicType, error)
+	parseNotExpression(p Parser) (language.IBasicType, error)
+	parseComparisonExpression(p Parser) (language.IBasicType, error)
+	parseExpression(p Parser) (language.IBasicType, error)
+	parseTerm(p Parser) (language.IBasicType, error)
+	parseFactor(p Parser) (language.IBasicType, error)
+	parseUnary(p Parser) (language.IBasicType, error)
+	parseConversion(p Parser) (language.IBasicType, error)
+	parseFunctionLiteral(p Parser) (language.IBasicType, error)
+	parseStructLiteral(p Parser) (language.IBasicType, error)
+	parseStringInterpolation(p Parser) (language.IBasicType, error)
+	parseTerminal() (language.IBasicType, error)
 }
 
 type Lexer interface {
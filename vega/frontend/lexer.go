@@ -9,6 +9,7 @@ package frontend
 import (
 	"bytes"
 	"io"
+	"unicode/utf8"
 
 	"govega/vega/helper"
 	"govega/vega/language"
@@ -24,6 +25,7 @@ type tokenLocation struct {
 // lexicalToken is a token wrapper which also contains information where the token origins in the code
 type lexicalToken struct {
 	token tokens.IToken
+	doc   string // documentation comment attached to a function declaration
 	tokenLocation
 }
 
@@ -41,21 +43,38 @@ func (t *lexicalToken) GetTag() int {
 	return t.token.GetTag()
 }
 
+// GetDoc getter method to retrieve the documentation comment preceding a func keyword
+func (t *lexicalToken) GetDoc() string {
+	return t.doc
+}
+
 // lexer implements the lexer object
 type lexer struct {
 	*vega
-	peek     rune             // holds the current scanned character
-	code     *bytes.Reader    // code to be analysed in memory
-	words    helper.HashTable // collection of keywords and identifiers
-	lineFeed string
-	line     int
-	position int
-	eof      bool
+	peek           rune             // holds the current scanned character
+	code           *bytes.Reader    // code to be analysed in memory
+	words          helper.HashTable // collection of keywords and identifiers
+	lineFeed       string
+	line           int
+	position       int
+	eof            bool
+	docComment     string // collected /// comment lines waiting to be attached to the next func keyword
+	interpolations []int  // open curly brackets for each interpolated expression which is currently scanned
+	brackets       []int  // tags of all currently open brackets
+	lastTag        int    // tag of the last scanned token to decide if a line break terminates a statement
+	tokenStart     int    // position of the first character of the currently scanned token
+	version        LanguageVersion
 }
 
-// NewLexer creates a new lexer object
+// NewLexer creates a new lexer object for the language version of the project
 func (v *vega) NewLexer(code []byte) Lexer {
-	var lexer Lexer = &lexer{
+	var lexer Lexer = newLexer(v, code, v.languageVersion())
+	return lexer
+}
+
+// newLexer creates a lexer for the given language version, which decides if a line break after a type ends a statement
+func newLexer(v *vega, code []byte, version LanguageVersion) *lexer {
+	return &lexer{
 		vega:     v,
 		peek:     0,
 		code:     bytes.NewReader(code),
@@ -63,8 +82,8 @@ func (v *vega) NewLexer(code []byte) Lexer {
 		lineFeed: "",
 		line:     1,
 		position: 0,
+		version:  version,
 	}
-	return lexer
 }
 
 func (l *lexer) getLineFeed() string {
@@ -72,16 +91,50 @@ func (l *lexer) getLineFeed() string {
 }
 
 // newLexicalToken creates a new lexical token
-// As the position is calculated during lexer we need to substract the token length to get the starting location
+// The position of the token is the position of its first character which is remembered by scan
 func (l *lexer) newLexicalToken(token tokens.IToken) *lexicalToken {
-	var tokenPosition int
-	if l.position-len(token.String()) < 0 {
-		tokenPosition = l.position
-	} else {
-		tokenPosition = l.position - len(token.String())
+	loc := tokenLocation{line: l.line, position: l.tokenStart}
+	lexToken := &lexicalToken{token: token, tokenLocation: loc}
+	// documentation comments are only kept for the directly following function declaration
+	switch token.GetTag() {
+	case tokens.FUNC:
+		lexToken.doc = l.docComment
+		l.docComment = ""
+	case tokens.LINEBREAK:
+	default:
+		l.docComment = ""
+	}
+	switch token.GetTag() {
+	case tokens.LBRACKET, tokens.LSBRACKET, tokens.LCBRACKET:
+		l.brackets = append(l.brackets, token.GetTag())
+	case tokens.RBRACKET, tokens.RSBRACKET, tokens.RCBRACKET:
+		if n := len(l.brackets); n > 0 {
+			l.brackets = l.brackets[:n-1]
+		}
+	}
+	l.lastTag = token.GetTag()
+	return lexToken
+}
+
+// insertDelimiter private method to decide if a line break terminates a statement. This is the case when the last token
+// can end a statement (see language.StatementEndings) and the line break is not enclosed in round or square brackets.
+// With type annotations a declaration can also end with a type keyword like int or str.
+func (l *lexer) insertDelimiter() bool {
+	if n := len(l.brackets); n > 0 && l.brackets[n-1] != tokens.LCBRACKET {
+		return false
+	}
+	if (l.lastTag == tokens.BASIC || l.lastTag == tokens.TYPE) && l.version >= Version2 {
+		return true
 	}
-	loc := tokenLocation{line: l.line, position: tokenPosition}
-	return &lexicalToken{token: token, tokenLocation: loc}
+	return language.StatementEndings[l.lastTag]
+}
+
+// newLine private method to store the finished line for error output and to reset the position for the next line
+func (l *lexer) newLine() {
+	l.codeLines = append(l.codeLines, l.lineFeed)
+	l.lineFeed = ""
+	l.position = 0
+	l.line++
 }
 
 // unreadch private method to put the last read character back on the code stream (revert previous readch)
@@ -90,7 +143,8 @@ func (l *lexer) unreadch() error {
 		if err := l.code.UnreadRune(); err != nil {
 			return err
 		}
-		l.lineFeed = l.lineFeed[:len(l.lineFeed)-1]
+		_, size := utf8.DecodeLastRuneInString(l.lineFeed)
+		l.lineFeed = l.lineFeed[:len(l.lineFeed)-size]
 		l.position--
 	}
 	l.peek = 0
@@ -137,33 +191,57 @@ func (l *lexer) scanCombinedTokens(fch rune, sch rune, word tokens.IWord) (*lexi
 	if ok {
 		return l.newLexicalToken(word), nil
 	} else {
+		// the second character belongs to the next token
+		if err := l.unreadch(); err != nil {
+			return nil, err
+		}
 		var tok tokens.IToken
 		switch fch {
 		case '!':
 			tok = tokens.NewToken(tokens.EXCLAMATION)
 		case '=':
 			tok = tokens.NewToken(tokens.ASSIGN)
-		case '<':
-			tok = tokens.NewToken(tokens.LESS)
-		case '>':
-			tok = tokens.NewToken(tokens.GREATER)
-		case '|':
-			tok = tokens.NewToken(tokens.LOGOR)
-		case '&':
-			tok = tokens.NewToken(tokens.LOGAND)
 		}
 		return l.newLexicalToken(tok), nil
 	}
 }
 
+// scanOperator private method to scan operators which can be combined with the following characters, e.g. <, <=, <<
+// and <<=. The longest combination is returned, the single operator if no combination matches.
+func (l *lexer) scanOperator(fch rune, single tokens.IToken) (*lexicalToken, error) {
+	combinations := language.Operators[fch]
+	token := single
+	suffix := ""
+	for {
+		if err := l.readch(); err != nil {
+			return nil, err
+		}
+		word, ok := combinations[suffix+string(l.peek)]
+		if !ok || l.peek == 0 {
+			// the character does not belong to the operator and is scanned again as part of the next token
+			if err := l.unreadch(); err != nil {
+				return nil, err
+			}
+			return l.newLexicalToken(token), nil
+		}
+		suffix += string(l.peek)
+		token = word
+	}
+}
+
 // scanLiterals private method to scan all types of literals.
 func (l *lexer) scanLiterals(indicator rune) (*lexicalToken, error) {
+	return l.scanLiteralParts(indicator, string(l.peek), false)
+}
+
+// scanLiteralParts private method to scan the content of a literal. Literals encapsulated in "" can contain
+// interpolated expressions ${...}. In this case the literal is split up: the part in front of the expression is returned
+// as token, the expression is scanned as normal tokens and the literal is resumed after the closing }.
+func (l *lexer) scanLiteralParts(indicator rune, literal string, resumed bool) (*lexicalToken, error) {
 	var (
-		literal string
-		char    rune
-		err     error
+		char rune
+		err  error
 	)
-	literal = string(l.peek)
 	err = l.readch()
 	for ; l.peek != indicator && err == nil; err = l.readch() {
 		if l.peek == '\n' || l.peek == 0 {
@@ -171,6 +249,23 @@ func (l *lexer) scanLiterals(indicator rune) (*lexicalToken, error) {
 			vErr := l.newLexicalSyntaxError(literalNotTerminated, l.line, l.position, "String literal not terminated")
 			return nil, vErr
 		}
+		if l.peek == '$' && indicator == '"' {
+			if err = l.readch(); err != nil {
+				return nil, err
+			}
+			if l.peek == '{' {
+				l.interpolations = append(l.interpolations, 0)
+				if resumed {
+					return l.newLexicalToken(tokens.NewStringPart(literal+"${", tokens.INTERPMID)), nil
+				}
+				return l.newLexicalToken(tokens.NewStringPart(literal+"${", tokens.INTERPSTART)), nil
+			}
+			if err = l.unreadch(); err != nil {
+				return nil, err
+			}
+			literal = literal + "$"
+			continue
+		}
 		if l.peek == '\\' {
 			err = l.readch()
 			if err != nil {
@@ -193,6 +288,14 @@ func (l *lexer) scanLiterals(indicator rune) (*lexicalToken, error) {
 				char = '\v'
 			case '\\':
 				char = '\\'
+			case '$':
+				if indicator == '"' {
+					char = '$'
+				} else {
+					l.codeLines = append(l.codeLines, l.lineFeed)
+					vErr := l.newLexicalSyntaxError(invalidEscapeSequence, l.line, l.position, "Invalid escape sequence")
+					return nil, vErr
+				}
 			case '"':
 				if indicator == '"' {
 					char = '"'
@@ -210,66 +313,30 @@ func (l *lexer) scanLiterals(indicator rune) (*lexicalToken, error) {
 					return nil, vErr
 				}
 			case 'x':
-				hex := ""
-				for i := 0; i < 2; i++ {
-					err = l.readch()
-					if err != nil {
-						l.codeLines = append(l.codeLines, l.lineFeed)
-						vErr := l.newLexicalSyntaxError(invalidEscapeSequenceHexadecimal, l.line, l.position, "Invalid hexadecimal literal. Must contain two digits between 00-FF")
-						return nil, vErr
-					}
-					// transform uppercase hex in lowercase for lookup
-					if l.peek > 64 && l.peek < 91 {
-						l.peek = l.peek + 32
-					}
-					hex = hex + string(l.peek)
-				}
-				hexLookup, ok := language.EscapeHexaLiterals.Get(hex)
-				if !ok {
+				hex, hexErr := l.readEscapeDigits(2)
+				hexValue, ok := language.DecodeEscapeDigits(hex, 16)
+				if hexErr != nil || !ok {
 					l.codeLines = append(l.codeLines, l.lineFeed)
 					vErr := l.newLexicalSyntaxError(invalidEscapeSequenceHexadecimal, l.line, l.position, "Invalid hexadecimal literal. Must contain two digits between 00-FF")
 					return nil, vErr
 				}
-				char = hexLookup.(rune)
-			case 'u':
-				unicode := ""
-				for i := 0; i < 4; i++ {
-					err = l.readch()
-					if err != nil {
-						l.codeLines = append(l.codeLines, l.lineFeed)
-						vErr := l.newLexicalSyntaxError(invalidEscapeSequenceUnicode, l.line, l.position, "Invalid unicode literal. Must contain four digits between 0000-FFFF")
-						return nil, vErr
-					}
-					if l.peek > 64 && l.peek < 91 {
-						l.peek = l.peek + 32
-					}
-					unicode = unicode + string(l.peek)
+				char = hexValue
+			case 'u', 'U':
+				unicodeValue, unicodeErr := l.scanUnicodeEscape()
+				if unicodeErr != nil {
+					return nil, unicodeErr
 				}
-				unicodeLookup, ok := language.EscapeUnicodeLiterals.Get(unicode)
-				if !ok {
-					l.codeLines = append(l.codeLines, l.lineFeed)
-					vErr := l.newLexicalSyntaxError(invalidEscapeSequenceUnicode, l.line, l.position, "Invalid unicode literal. Must contain four digits between 0000-FFFF")
-					return nil, vErr
-				}
-				char = unicodeLookup.(rune)
+				char = unicodeValue
 			case '0', '1', '2', '3':
 				oct := string(l.peek)
-				for i := 0; i < 2; i++ {
-					err = l.readch()
-					if err != nil {
-						l.codeLines = append(l.codeLines, l.lineFeed)
-						vErr := l.newLexicalSyntaxError(invalidEscapeSequenceOctal, l.line, l.position, "Invalid octal literal. Must contain three digits between 000-377")
-						return nil, vErr
-					}
-					oct = oct + string(l.peek)
-				}
-				octLookup, ok := language.EscapeOctalLiterals.Get(oct)
-				if !ok {
+				digits, octErr := l.readEscapeDigits(2)
+				octValue, ok := language.DecodeEscapeDigits(oct+digits, 8)
+				if octErr != nil || !ok {
 					l.codeLines = append(l.codeLines, l.lineFeed)
 					vErr := l.newLexicalSyntaxError(invalidEscapeSequenceOctal, l.line, l.position, "Invalid octal literal. Must contain three digits between 000-377")
 					return nil, vErr
 				}
-				char = octLookup.(rune)
+				char = octValue
 			default:
 				l.codeLines = append(l.codeLines, l.lineFeed)
 				vErr := l.newLexicalSyntaxError(invalidEscapeSequence, l.line, l.position, "Invalid escape sequence")
@@ -286,9 +353,92 @@ func (l *lexer) scanLiterals(indicator rune) (*lexicalToken, error) {
 		return nil, vErr
 	}
 	literal = literal + string(l.peek)
+	if resumed {
+		return l.newLexicalToken(tokens.NewStringPart(literal, tokens.INTERPEND)), nil
+	}
 	return l.newLexicalToken(tokens.NewLiteral(literal)), nil
 }
 
+// readEscapeDigits private method to read the given number of characters belonging to a numeric escape sequence
+func (l *lexer) readEscapeDigits(count int) (string, error) {
+	var digits string
+	for i := 0; i < count; i++ {
+		if err := l.readch(); err != nil {
+			return "", err
+		}
+		digits += string(l.peek)
+	}
+	return digits, nil
+}
+
+// scanUnicodeEscape private method to decode the unicode escape sequences \uXXXX, \UXXXXXXXX and \u{X} with one to six
+// hexadecimal digits. Surrogate halves (D800-DFFF) and code points above 10FFFF are not valid characters.
+func (l *lexer) scanUnicodeEscape() (rune, error) {
+	var (
+		digits string
+		err    error
+	)
+	msg := "Invalid unicode literal. Must contain four digits between 0000-FFFF"
+	switch {
+	case l.peek == 'U':
+		msg = "Invalid unicode literal. Must contain eight digits between 00000000-0010FFFF"
+		digits, err = l.readEscapeDigits(8)
+	default:
+		if err = l.readch(); err != nil {
+			break
+		}
+		if l.peek != '{' {
+			first := string(l.peek)
+			digits, err = l.readEscapeDigits(3)
+			digits = first + digits
+			break
+		}
+		msg = "Invalid unicode literal. Must contain one to six digits between {0}-{10FFFF}"
+		for err = l.readch(); l.peek != '}' && len(digits) < 6 && err == nil; err = l.readch() {
+			digits += string(l.peek)
+		}
+		if l.peek != '}' {
+			digits = ""
+		}
+	}
+	value, ok := language.DecodeEscapeDigits(digits, 16)
+	if err != nil || !ok {
+		l.codeLines = append(l.codeLines, l.lineFeed)
+		return 0, l.newLexicalSyntaxError(invalidEscapeSequenceUnicode, l.line, l.position, msg)
+	}
+	if !utf8.ValidRune(value) {
+		l.codeLines = append(l.codeLines, l.lineFeed)
+		return 0, l.newLexicalSyntaxError(invalidEscapeSequenceUnicode, l.line, l.position, "Invalid unicode literal. Must be a code point between 0-10FFFF excluding surrogates D800-DFFF")
+	}
+	return value, nil
+}
+
+// scanRawLiterals private method to scan raw literals encapsulated in ``. Raw literals can span multiple lines and
+// escape sequences are not interpreted.
+func (l *lexer) scanRawLiterals() (*lexicalToken, error) {
+	var err error
+	literal := string(l.peek)
+	line, position := l.line, l.position-1
+	for err = l.readch(); l.peek != '`' && err == nil; err = l.readch() {
+		if l.peek == 0 {
+			l.codeLines = append(l.codeLines, l.lineFeed)
+			vErr := l.newLexicalSyntaxError(literalNotTerminated, line, position, "Raw literal not terminated")
+			return nil, vErr
+		}
+		literal = literal + string(l.peek)
+		if l.peek == '\n' {
+			l.newLine()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	literal = literal + string(l.peek)
+	token := l.newLexicalToken(tokens.NewLiteral(literal))
+	token.line, token.position = line, position
+	return token, nil
+}
+
 // scanNumbers private method to scan integer and floating point numbers
 func (l *lexer) scanNumbers() (*lexicalToken, error) {
 	var (
@@ -325,7 +475,7 @@ func (l *lexer) scanWords() (*lexicalToken, error) {
 		word string
 		err  error
 	)
-	for ; ((l.peek > 64 && l.peek < 91) || (l.peek > 96 && l.peek < 123) || (l.peek > 47 && l.peek < 58)) && err == nil; err = l.readch() {
+	for ; ((l.peek > 64 && l.peek < 91) || (l.peek > 96 && l.peek < 123) || (l.peek > 47 && l.peek < 58) || l.peek == '_') && err == nil; err = l.readch() {
 		word += string(l.peek)
 	}
 	if err != nil {
@@ -340,17 +490,18 @@ func (l *lexer) scanWords() (*lexicalToken, error) {
 	return l.newLexicalToken(identifier), nil
 }
 
-// scanComments private method which skips all single and multi-line comments
+// scanComments private method which skips all single and multi-line comments. Multi-line comments can be nested,
+// single line comments starting with /// are collected as documentation for the following function declaration.
 func (l *lexer) scanComments() (*lexicalToken, error) {
-	var (
-		err error
-		ok  bool
-	)
+	var err error
 	if err = l.readch(); err != nil {
 		return nil, err
 	}
-	if l.peek == '/' {
-		for ; l.peek != '\n' && l.peek != 0 && err == nil; err = l.readch() {
+	switch l.peek {
+	case '/':
+		var comment string
+		for err = l.readch(); l.peek != '\n' && l.peek != 0 && err == nil; err = l.readch() {
+			comment += string(l.peek)
 		}
 		if err != nil {
 			return nil, err
@@ -358,42 +509,109 @@ func (l *lexer) scanComments() (*lexicalToken, error) {
 		if err = l.unreadch(); err != nil {
 			return nil, err
 		}
-	} else if l.peek == '*' {
-		for ; err == nil; err = l.readch() {
-			if l.peek == '\n' {
-				l.line++
-			} else if l.peek == '*' {
-				ok, err = l.readcch('/')
-				if ok || err != nil {
-					break
-				}
-			}
+		// only exactly three slashes mark a documentation comment, //// is an ordinary comment
+		if len(comment) > 0 && comment[0] == '/' && (len(comment) == 1 || comment[1] != '/') {
+			l.addDocComment(comment[1:])
 		}
-		if err != nil {
+	case '*':
+		line := l.line
+		if err = l.skipBlockComment(); err != nil {
+			return nil, err
+		}
+		// like a line break a multi-line comment can terminate a statement
+		if l.line > line && l.insertDelimiter() {
+			l.tokenStart = l.position - 1
+			return l.newLexicalToken(tokens.NewToken(tokens.LINEBREAK)), nil
+		}
+	case '=':
+		return l.newLexicalToken(language.DivAssign), nil
+	default:
+		if err = l.unreadch(); err != nil {
 			return nil, err
 		}
-	} else {
 		return l.newLexicalToken(tokens.NewToken(tokens.DIV)), nil
 	}
 	return nil, nil
 }
 
+// addDocComment private method to append a documentation comment line. A single leading space is stripped.
+func (l *lexer) addDocComment(comment string) {
+	if len(comment) > 0 && comment[0] == ' ' {
+		comment = comment[1:]
+	}
+	if l.docComment == "" {
+		l.docComment = comment
+	} else {
+		l.docComment += "\n" + comment
+	}
+}
+
+// skipBlockComment private method to skip a (nested) multi-line comment after the opening /* has been read. Line
+// breaks inside the comment are counted like line breaks in code, so later errors report the correct line.
+func (l *lexer) skipBlockComment() error {
+	var err error
+	depth := 1
+	line, position := l.line, l.position-2
+	for depth > 0 {
+		if err = l.readch(); err != nil {
+			return err
+		}
+		switch l.peek {
+		case 0:
+			l.codeLines = append(l.codeLines, l.lineFeed)
+			return l.newLexicalSyntaxError(unterminatedComment, line, position, "Comment not terminated")
+		case '\n':
+			l.newLine()
+		case '*':
+			if err = l.readch(); err != nil {
+				return err
+			}
+			if l.peek == '/' {
+				depth--
+			} else if err = l.unreadch(); err != nil {
+				return err
+			}
+		case '/':
+			if err = l.readch(); err != nil {
+				return err
+			}
+			if l.peek == '*' {
+				depth++
+			} else if err = l.unreadch(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // scan public method to scan the actual source code and return a tokenStream with all scanned tokens
 func (l *lexer) scan() (*lexicalToken, error) {
 	err := l.readch()
 	for ; err == nil; err = l.readch() {
+		l.tokenStart = l.position - 1
 		if l.peek == 0 {
+			l.tokenStart = l.position
+			// the end of file terminates the last statement like a line break
+			if l.insertDelimiter() {
+				return l.newLexicalToken(tokens.NewToken(tokens.LINEBREAK)), nil
+			}
 			return l.newLexicalToken(tokens.NewToken(tokens.EOF)), nil
 		}
 		switch {
-		// skip line breaks
+		// line breaks are returned only if they terminate a statement, otherwise they are skipped
 		case l.peek == '\n':
-			token := l.newLexicalToken(tokens.NewToken(tokens.LINEBREAK))
-			l.codeLines = append(l.codeLines, l.lineFeed)
-			l.lineFeed = ""
-			l.position = 0
-			l.line++
-			return token, nil
+			// interpolated expressions are part of a literal and can not span multiple lines
+			if len(l.interpolations) > 0 {
+				l.codeLines = append(l.codeLines, l.lineFeed)
+				return nil, l.newLexicalSyntaxError(literalNotTerminated, l.line, l.position, "String literal not terminated")
+			}
+			if l.insertDelimiter() {
+				token := l.newLexicalToken(tokens.NewToken(tokens.LINEBREAK))
+				l.newLine()
+				return token, nil
+			}
+			l.newLine()
 		// skip comments
 		case l.peek == '/':
 			var token *lexicalToken
@@ -409,21 +627,30 @@ func (l *lexer) scan() (*lexicalToken, error) {
 		// read token = or ==
 		case l.peek == '=':
 			return l.scanCombinedTokens(l.peek, '=', language.Eq)
-		// read token < or <=
+		// read token <, <=, << or <<=
 		case l.peek == '<':
-			return l.scanCombinedTokens(l.peek, '=', language.Le)
-		// read token > or >=
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.LESS))
+		// read token >, >=, >> or >>=
 		case l.peek == '>':
-			return l.scanCombinedTokens(l.peek, '=', language.Ge)
-		// read token & or &&
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.GREATER))
+		// read token &, && or &=
 		case l.peek == '&':
-			return l.scanCombinedTokens(l.peek, '&', language.BoolAnd)
-		// read token | or ||
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.LOGAND))
+		// read token |, || or |=
 		case l.peek == '|':
-			return l.scanCombinedTokens(l.peek, '|', language.BoolOr)
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.LOGOR))
+		// read token ^ or ^=
+		case l.peek == '^':
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.XOR))
+		// read token % or %=
+		case l.peek == '%':
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.MOD))
 		// read literals encapsulated in '' or ""
 		case l.peek == '\'', l.peek == '"':
 			return l.scanLiterals(l.peek)
+		// read raw literals encapsulated in ``
+		case l.peek == '`':
+			return l.scanRawLiterals()
 		// read numbers
 		case l.peek > 47 && l.peek < 58:
 			tok, err := l.scanNumbers()
@@ -433,27 +660,40 @@ func (l *lexer) scan() (*lexicalToken, error) {
 			err = l.unreadch()
 			return tok, err
 		// read words
-		case (l.peek > 64 && l.peek < 91) || (l.peek > 96 && l.peek < 123):
+		case (l.peek > 64 && l.peek < 91) || (l.peek > 96 && l.peek < 123) || l.peek == '_':
 			tok, err := l.scanWords()
 			if err != nil {
 				return nil, err
 			}
 			err = l.unreadch()
 			return tok, err
-			// read +
+			// read +, ++ or +=
 		case l.peek == '+':
-			return l.newLexicalToken(tokens.NewToken(tokens.ADD)), nil
-			// read -
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.ADD))
+			// read -, -- or -=
 		case l.peek == '-':
-			return l.newLexicalToken(tokens.NewToken(tokens.SUB)), nil
-			// read *
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.SUB))
+			// read * or *=
 		case l.peek == '*':
-			return l.newLexicalToken(tokens.NewToken(tokens.MULT)), nil
+			return l.scanOperator(l.peek, tokens.NewToken(tokens.MULT))
+			// read ~
+		case l.peek == '~':
+			return l.newLexicalToken(tokens.NewToken(tokens.TILDE)), nil
 			// read {
 		case l.peek == '{':
+			if n := len(l.interpolations); n > 0 {
+				l.interpolations[n-1]++
+			}
 			return l.newLexicalToken(tokens.NewToken(tokens.LCBRACKET)), nil
-			// read }
+			// read } or resume an interpolated literal
 		case l.peek == '}':
+			if n := len(l.interpolations); n > 0 {
+				if l.interpolations[n-1] == 0 {
+					l.interpolations = l.interpolations[:n-1]
+					return l.scanLiteralParts('"', "}", true)
+				}
+				l.interpolations[n-1]--
+			}
 			return l.newLexicalToken(tokens.NewToken(tokens.RCBRACKET)), nil
 			// read [
 		case l.peek == '[':
@@ -476,8 +716,12 @@ func (l *lexer) scan() (*lexicalToken, error) {
 			// read ,
 		case l.peek == ',':
 			return l.newLexicalToken(tokens.NewToken(tokens.COMMA)), nil
+			// read .
+		case l.peek == '.':
+			return l.newLexicalToken(tokens.NewToken(tokens.DOT)), nil
 		// token not in alphabet
 		default:
+			l.codeLines = append(l.codeLines, l.lineFeed)
 			return nil, l.newLexicalSyntaxError(invalidCharacter, l.line, l.position, "Invalid character")
 		}
 		if err != nil {
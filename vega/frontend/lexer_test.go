@@ -113,6 +113,7 @@ func TestCombinedTokens(t *testing.T) {
 	}{
 		{"!=", tokens.NE},
 		{"!-", tokens.EXCLAMATION},
+		{"!", tokens.EXCLAMATION},
 	}
 
 	for i, tc := range tests {
@@ -157,6 +158,22 @@ func TestScanLiterals(t *testing.T) {
 			"'my \\123 \\\\'",
 			LiteralTestWant{tokens.LITERAL, "'my \123 \\'"},
 		},
+		{
+			"'\\u00E4 \\U0001F600 \\u{1F600} \\u{a}'",
+			LiteralTestWant{tokens.LITERAL, "'\u00e4 \U0001F600 \U0001F600 \n'"},
+		},
+		{
+			"\"costs $5\"",
+			LiteralTestWant{tokens.LITERAL, "\"costs $5\""},
+		},
+		{
+			"\"escaped \\${x}\"",
+			LiteralTestWant{tokens.LITERAL, "\"escaped ${x}\""},
+		},
+		{
+			"'no ${interpolation}'",
+			LiteralTestWant{tokens.LITERAL, "'no ${interpolation}'"},
+		},
 	}
 	for i, tc := range tests {
 		test := fmt.Sprintf("test%d", i+1)
@@ -215,6 +232,36 @@ func TestScanLiteralsFailures(t *testing.T) {
 			"'foo\\088Bar'",
 			invalidEscapeSequenceOctal,
 		},
+		{
+			"too short unicode escape sequence",
+			"'foo\\u12'",
+			invalidEscapeSequenceUnicode,
+		},
+		{
+			"surrogate unicode escape sequence",
+			"'foo\\uD800'",
+			invalidEscapeSequenceUnicode,
+		},
+		{
+			"unicode escape sequence out of range",
+			"'foo\\U00110000'",
+			invalidEscapeSequenceUnicode,
+		},
+		{
+			"empty braced unicode escape sequence",
+			"'foo\\u{}'",
+			invalidEscapeSequenceUnicode,
+		},
+		{
+			"too long braced unicode escape sequence",
+			"'foo\\u{0000041}'",
+			invalidEscapeSequenceUnicode,
+		},
+		{
+			"braced surrogate unicode escape sequence",
+			"'foo\\u{DFFF}'",
+			invalidEscapeSequenceUnicode,
+		},
 		{
 			"no literal terminator",
 			"'fooBar",
@@ -238,6 +285,91 @@ func TestScanLiteralsFailures(t *testing.T) {
 	}
 }
 
+func TestLexer_ScanRawLiterals(t *testing.T) {
+	in := "`raw \\n ${x}\nmulti-line \"literal\"`;"
+	want := "`raw \\n ${x}\nmulti-line \"literal\"`"
+	lexer := newTestLexer([]string{}, []byte(in))
+
+	token, err := lexer.scan()
+	if err != nil {
+		t.Fatalf("Error reading raw literal: %v", err)
+	}
+	if token.GetTag() != tokens.LITERAL {
+		t.Fatalf("Want token to be %v, but got: %v", tokens.LITERAL, token.GetTag())
+	}
+	if got := token.GetToken().(tokens.ILiteral).GetContent(); got != want {
+		t.Fatalf("Want literal to be %v, but got: %v", want, got)
+	}
+	if line, _ := token.GetLocation(); line != 1 {
+		t.Fatalf("Want raw literal to start in line 1, but got: %v", line)
+	}
+	if lexer.getLine() != 2 {
+		t.Fatalf("Want lexer to be in line 2, but got: %v", lexer.getLine())
+	}
+
+	lexer = newTestLexer([]string{}, []byte("`not terminated\n"))
+	_, err = lexer.scan()
+	if GetVErrorType(err) != literalNotTerminated {
+		t.Fatalf("Expected vega Error type to be %v, but got %v", literalNotTerminated, GetVErrorType(err))
+	}
+}
+
+func TestLexer_ScanInterpolation(t *testing.T) {
+	type part struct {
+		tag     int
+		content string
+	}
+	tests := []struct {
+		in   string
+		want []part
+	}{
+		{
+			"\"x = ${x}\"",
+			[]part{{tokens.INTERPSTART, "\"x = ${"}, {tokens.ID, "x"}, {tokens.INTERPEND, "}\""}, {tokens.LINEBREAK, "\n"}},
+		},
+		{
+			"\"${a + 1} and ${b}!\"",
+			[]part{
+				{tokens.INTERPSTART, "\"${"}, {tokens.ID, "a"}, {tokens.ADD, "+"}, {tokens.NUM, "1"},
+				{tokens.INTERPMID, "} and ${"}, {tokens.ID, "b"}, {tokens.INTERPEND, "}!\""}, {tokens.LINEBREAK, "\n"},
+			},
+		},
+		{
+			"\"outer ${\"inner ${c}\"} end\"",
+			[]part{
+				{tokens.INTERPSTART, "\"outer ${"}, {tokens.INTERPSTART, "\"inner ${"}, {tokens.ID, "c"},
+				{tokens.INTERPEND, "}\""}, {tokens.INTERPEND, "} end\""}, {tokens.LINEBREAK, "\n"},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		lexer := newTestLexer([]string{}, []byte(tc.in))
+
+		for _, want := range tc.want {
+			token, err := lexer.scan()
+			if err != nil {
+				t.Fatalf("%v: Unexpected error: %v", test, err)
+			}
+			if token.GetTag() != want.tag || token.GetToken().String() != want.content {
+				t.Fatalf("%v: Want token %v %q, but got %v %q", test, want.tag, want.content, token.GetTag(), token.GetToken().String())
+			}
+		}
+		if token, _ := lexer.scan(); token.GetTag() != tokens.EOF {
+			t.Fatalf("%v: Want EOF, but got %v", test, token.GetToken().String())
+		}
+	}
+
+	lexer := newTestLexer([]string{}, []byte("\"${a\n}\""))
+	var err error
+	for ; err == nil; _, err = lexer.scan() {
+	}
+	if GetVErrorType(err) != literalNotTerminated {
+		t.Fatalf("Expected vega Error type to be %v, but got %v", literalNotTerminated, GetVErrorType(err))
+	}
+}
+
 func TestScanNumbers(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -285,6 +417,8 @@ func TestScanWords(t *testing.T) {
 	}{
 		{"while", tokens.NewWord("while", tokens.WHILE)},
 		{"var1", tokens.NewWord("var1", tokens.ID)},
+		{"to_str", tokens.NewWord("to_str", tokens.ID)},
+		{"_tmp", tokens.NewWord("_tmp", tokens.ID)},
 	}
 
 	for i, tc := range tests {
@@ -317,6 +451,9 @@ func TestLexer_scanComments(t *testing.T) {
 	}{
 		{"// this is a test comment\n", '\n'},
 		{"/* this\nis\na\nmulti-line\ncomment\n*/x", 'x'},
+		{"/* outer /* inner */ still outer */x", 'x'},
+		{"/* stars **/x", 'x'},
+		{"/// doc comment\n", '\n'},
 	}
 	for i, tc := range tests {
 		test := fmt.Sprintf("test%d", i+1)
@@ -343,6 +480,58 @@ func TestLexer_scanComments(t *testing.T) {
 	}
 }
 
+func TestLexer_scanCommentsUnterminated(t *testing.T) {
+	tests := []string{
+		"/* never closed",
+		"/* outer /* inner */ never closed\n",
+	}
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		lexer := newTestLexer([]string{}, []byte(tc))
+
+		err := lexer.readch()
+		if err != nil {
+			t.Error(err)
+		}
+
+		_, err = lexer.scanComments()
+		if GetVErrorType(err) != unterminatedComment {
+			t.Fatalf("%v: Expected vega Error type to be %v, but got %v", test, unterminatedComment, GetVErrorType(err))
+		}
+	}
+}
+
+func TestLexer_ScanDocComments(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"/// adds two numbers\nfunc", "adds two numbers"},
+		{"/// first line\n///second line\nfunc", "first line\nsecond line"},
+		{"// no doc comment\nfunc", ""},
+		{"//// no doc comment\nfunc", ""},
+		{"/// detached doc comment\nint\nfunc", ""},
+		{"/// survives /* block */ comments\n/* block */\nfunc", "survives /* block */ comments"},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		lexer := newTestLexer([]string{}, []byte(tc.in))
+
+		var token *lexicalToken
+		var err error
+		for token, err = lexer.scan(); err == nil && token.GetTag() != tokens.FUNC; token, err = lexer.scan() {
+		}
+		if err != nil {
+			t.Fatalf("%v: Unexpected error: %v", test, err)
+		}
+
+		if token.GetDoc() != tc.want {
+			t.Fatalf("%v: Want doc comment %q, but got %q", test, tc.want, token.GetDoc())
+		}
+	}
+}
+
 func TestLexer_Scan(t *testing.T) {
 	tests := []struct {
 		in         string
@@ -355,19 +544,98 @@ func TestLexer_Scan(t *testing.T) {
 			"}",
 			4,
 			[]interface{}{
-				tokens.NewToken(tokens.LINEBREAK),
 				tokens.NewWord("func", tokens.FUNC),
 				tokens.NewWord("test", tokens.ID),
 				tokens.NewToken(tokens.LBRACKET),
 				tokens.NewToken(tokens.RBRACKET),
 				language.BoolType,
 				tokens.NewToken(tokens.LCBRACKET),
-				tokens.NewToken(tokens.LINEBREAK),
 				tokens.NewWord("return", tokens.RETURN),
 				tokens.NewWord("true", tokens.TRUE),
 				tokens.NewToken(tokens.DELIMITER),
+				tokens.NewToken(tokens.RCBRACKET),
 				tokens.NewToken(tokens.LINEBREAK),
+				tokens.NewToken(tokens.EOF),
+			}},
+		{"struct Point { float x }\n" +
+			"p.x = 1.5",
+			2,
+			[]interface{}{
+				tokens.NewWord("struct", tokens.STRUCT),
+				tokens.NewWord("Point", tokens.ID),
+				tokens.NewToken(tokens.LCBRACKET),
+				language.FloatType,
+				tokens.NewWord("x", tokens.ID),
 				tokens.NewToken(tokens.RCBRACKET),
+				tokens.NewToken(tokens.LINEBREAK),
+				tokens.NewWord("p", tokens.ID),
+				tokens.NewToken(tokens.DOT),
+				tokens.NewWord("x", tokens.ID),
+				tokens.NewToken(tokens.ASSIGN),
+				tokens.NewToken(tokens.REAL),
+				tokens.NewToken(tokens.LINEBREAK),
+				tokens.NewToken(tokens.EOF),
+			}},
+		{"a<b<<c<<=~d>e>>f>>=g%h%=i^j^=k&l&=m|n|=o\n" +
+			"p++\n" +
+			"q-- + r += s-t -= u*v *= w/x /= y!z",
+			3,
+			[]interface{}{
+				tokens.NewWord("a", tokens.ID),
+				tokens.NewToken(tokens.LESS),
+				tokens.NewWord("b", tokens.ID),
+				language.Shl,
+				tokens.NewWord("c", tokens.ID),
+				language.ShlAssign,
+				tokens.NewToken(tokens.TILDE),
+				tokens.NewWord("d", tokens.ID),
+				tokens.NewToken(tokens.GREATER),
+				tokens.NewWord("e", tokens.ID),
+				language.Shr,
+				tokens.NewWord("f", tokens.ID),
+				language.ShrAssign,
+				tokens.NewWord("g", tokens.ID),
+				tokens.NewToken(tokens.MOD),
+				tokens.NewWord("h", tokens.ID),
+				language.ModAssign,
+				tokens.NewWord("i", tokens.ID),
+				tokens.NewToken(tokens.XOR),
+				tokens.NewWord("j", tokens.ID),
+				language.XorAssign,
+				tokens.NewWord("k", tokens.ID),
+				tokens.NewToken(tokens.LOGAND),
+				tokens.NewWord("l", tokens.ID),
+				language.AndAssign,
+				tokens.NewWord("m", tokens.ID),
+				tokens.NewToken(tokens.LOGOR),
+				tokens.NewWord("n", tokens.ID),
+				language.OrAssign,
+				tokens.NewWord("o", tokens.ID),
+				tokens.NewToken(tokens.LINEBREAK),
+				tokens.NewWord("p", tokens.ID),
+				language.Inc,
+				tokens.NewToken(tokens.LINEBREAK),
+				tokens.NewWord("q", tokens.ID),
+				language.Dec,
+				tokens.NewToken(tokens.ADD),
+				tokens.NewWord("r", tokens.ID),
+				language.AddAssign,
+				tokens.NewWord("s", tokens.ID),
+				tokens.NewToken(tokens.SUB),
+				tokens.NewWord("t", tokens.ID),
+				language.SubAssign,
+				tokens.NewWord("u", tokens.ID),
+				tokens.NewToken(tokens.MULT),
+				tokens.NewWord("v", tokens.ID),
+				language.MultAssign,
+				tokens.NewWord("w", tokens.ID),
+				tokens.NewToken(tokens.DIV),
+				tokens.NewWord("x", tokens.ID),
+				language.DivAssign,
+				tokens.NewWord("y", tokens.ID),
+				tokens.NewToken(tokens.EXCLAMATION),
+				tokens.NewWord("z", tokens.ID),
+				tokens.NewToken(tokens.LINEBREAK),
 				tokens.NewToken(tokens.EOF),
 			}},
 	}
@@ -426,6 +694,26 @@ func TestLexer_ScanNonCode(t *testing.T) {
 
 }
 
+func TestLexer_ScanErrorAfterBlockComment(t *testing.T) {
+	var err error
+	input := []string{"/* multi\n", "line\n", "comment */\n", "a = 'a\\-"}
+	inputCode := input[0] + input[1] + input[2] + input[3]
+	testVega := &vega{
+		file:      "/path/to/test.vg",
+		codeLines: input,
+	}
+	wantError := testVega.newLexicalSyntaxError(invalidEscapeSequence, 4, 8, "Invalid escape sequence")
+
+	lexer := newTestLexer([]string{}, []byte(inputCode))
+
+	for ; err == nil; _, err = lexer.scan() {
+	}
+
+	if wantError.Error() != err.Error() {
+		t.Fatalf("Expected:\n---\n%v\n---\nbut got:\n---\n%v\n---", wantError, err)
+	}
+}
+
 func TestLexer_ScanError(t *testing.T) {
 	var err error
 	input := []string{"// test doc string\n", "a = 'a\\-"}
@@ -449,3 +737,30 @@ func TestLexer_ScanError(t *testing.T) {
 		t.Fatalf("Expected:\n---\n%v\n---\nbut got:\n---\n%v\n---", wantError, err)
 	}
 }
+
+func TestLexer_ScanLineBreakAfterType(t *testing.T) {
+	tests := []struct {
+		version LanguageVersion
+		want    []int
+	}{
+		{Version1, []int{tokens.ID, tokens.COLON, tokens.BASIC, tokens.ID, tokens.ASSIGN, tokens.NUM, tokens.LINEBREAK, tokens.EOF}},
+		{Version2, []int{tokens.ID, tokens.COLON, tokens.BASIC, tokens.LINEBREAK, tokens.ID, tokens.ASSIGN, tokens.NUM, tokens.LINEBREAK, tokens.EOF}},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		// the lexer is created without a module loader, the version is passed directly
+		v := createTestVega("/path/to/test.vg", []string{})
+		lexer := newLexer(v.getVega(), []byte("a: int\nb = 1\n"), tc.version)
+
+		for _, want := range tc.want {
+			token, err := lexer.scan()
+			if err != nil {
+				t.Fatalf("%v: %v", test, err)
+			}
+			if token.GetTag() != want {
+				t.Fatalf("%v: Want token %v, but got %#v", test, want, token.GetToken())
+			}
+		}
+	}
+}
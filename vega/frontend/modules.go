@@ -0,0 +1,249 @@
+package frontend
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"govega/vega/frontend/utils"
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// module stores the declarations of a parsed package, every module is parsed once and all files of the package share
+// its symbol table
+type module struct {
+	path     string                         // import path used to load the module
+	files    []string                       // absolute paths of all source files of the package
+	table    *utils.SymbolTable             // package-level symbol table, the global scope holds the declarations
+	types    map[string]language.IBasicType // user-defined types of the package
+	globals  *initialization                // top level declarations of all files of the package
+	warnings []IVError                      // warnings found while parsing the files of the package
+}
+
+// moduleLoader resolves import paths and keeps track of all modules of a project
+type moduleLoader struct {
+	root       string
+	searchPath []string
+	modules    map[string]*module // loaded modules by their first source file
+	loading    []*module          // modules which are currently parsed, importing one of them again is a cycle
+	// global variables of all modules have to be initialized with constant expressions, e.g. for backends which
+	// emit the globals as static data
+	staticGlobals bool
+	version       LanguageVersion // declaration syntax of all modules
+	generics      *generics       // generic functions of all modules and their instances
+}
+
+// newModuleLoader creates a loader for the project in the root directory
+func newModuleLoader(root string, searchPath []string) *moduleLoader {
+	loader := &moduleLoader{
+		root:    absolutePath(root),
+		modules: map[string]*module{},
+	}
+	for _, dir := range searchPath {
+		loader.searchPath = append(loader.searchPath, absolutePath(dir))
+	}
+	return loader
+}
+
+// absolutePath returns the absolute path of a file to compare the files of different modules
+func absolutePath(file string) string {
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return filepath.Clean(file)
+}
+
+// exported checks if a declaration can be used by importing modules, which only refer to names starting with an
+// uppercase letter
+func exported(name string) bool {
+	first, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(first)
+}
+
+// validImportPath checks that the path is relative, stays inside the searched directories and ends with a name which
+// can be used as identifier to qualify the declarations of the module
+func validImportPath(importPath string) bool {
+	if importPath == "" || path.IsAbs(importPath) || path.Clean(importPath) != importPath {
+		return false
+	}
+	for _, element := range strings.Split(importPath, "/") {
+		if element == "." || element == ".." {
+			return false
+		}
+	}
+	for i, r := range path.Base(importPath) {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve returns the source files of the module with the given import path. The path is resolved relative to the
+// project root first and then relative to each directory of the search path, it either names a single file with the
+// extension .vg or a directory whose .vg files form one package.
+func (l *moduleLoader) resolve(importPath string) ([]string, bool) {
+	for _, dir := range append([]string{l.root}, l.searchPath...) {
+		location := filepath.Join(dir, filepath.FromSlash(importPath))
+		if info, err := os.Stat(location + ".vg"); err == nil && !info.IsDir() {
+			return []string{location + ".vg"}, true
+		}
+		if files, _ := filepath.Glob(filepath.Join(location, "*.vg")); len(files) > 0 {
+			return files, true
+		}
+	}
+	return nil, false
+}
+
+// cycle returns the chain of imports when one of the files belongs to a module which is currently parsed
+func (l *moduleLoader) cycle(importPath string, files []string) []string {
+	for i, loading := range l.loading {
+		for _, file := range loading.files {
+			for _, imported := range files {
+				if file != imported {
+					continue
+				}
+				var chain []string
+				for _, m := range l.loading[i:] {
+					chain = append(chain, m.path)
+				}
+				return append(chain, importPath)
+			}
+		}
+	}
+	return nil
+}
+
+// load parses all files of a module unless the module has been loaded before, the flag reports if the module has
+// been parsed by this call
+func (l *moduleLoader) load(importPath string, files []string) (*module, bool, error) {
+	if m, ok := l.modules[files[0]]; ok {
+		return m, false, nil
+	}
+	m := &module{
+		path:    importPath,
+		files:   files,
+		table:   newPackageTable(),
+		types:   map[string]language.IBasicType{},
+		globals: &initialization{},
+	}
+	l.loading = append(l.loading, m)
+	defer func() {
+		l.loading = l.loading[:len(l.loading)-1]
+	}()
+	for _, file := range files {
+		code, err := os.ReadFile(file)
+		if err != nil {
+			return nil, false, err
+		}
+		v := &vega{file: file, modules: l}
+		p := v.NewParser(v.NewLexer(code)).(*parser)
+		// all files of a package share their top level declarations
+		p.table = m.table
+		p.types = m.types
+		p.globals = m.globals
+		p.generics = l.generics
+		if err := p.Parse(p); err != nil {
+			return nil, false, err
+		}
+		m.warnings = append(m.warnings, p.warnings...)
+	}
+	l.modules[files[0]] = m
+	return m, true, nil
+}
+
+// moduleError returns a vega error on imports which cannot be resolved and on invalid references to imported names
+func (parser *parser) moduleError(errorType VErrorType, errorMessage string) error {
+	errMsg := fmt.Sprintf(errorMessage, parser.currentToken.GetToken().String())
+	return parser.newParserSyntaxError(errorType, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+}
+
+// parseImportDeclaration parses an import and loads the imported module, its exported declarations are referred to by
+// the last element of the import path
+//
+// importDeclaration
+//   : IMPORT LITERAL delimiter
+//   ;
+func (parser *parser) parseImportDeclaration(parserInterface Parser) error {
+	if !parser.matchToken(tokens.IMPORT) {
+		return parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.LITERAL) {
+		return parser.syntaxError("Mismatched input '%v', expected <module_path>")
+	}
+	literal := parser.currentToken.GetToken().(tokens.ILiteral)
+	if _, ok := literalType(literal).(*language.StringType); !ok {
+		return parser.syntaxError("Mismatched input '%v', expected <module_path>")
+	}
+	content := []rune(literal.GetContent())
+	importPath := string(content[1 : len(content)-1])
+	if !validImportPath(importPath) {
+		return parser.moduleError(invalidImport, "Invalid import path %v")
+	}
+	name := path.Base(importPath)
+	if _, ok := parser.imports[name]; ok {
+		return parser.moduleError(invalidImport, "Duplicate import of module %v")
+	}
+	if parser.modules == nil {
+		parser.modules = newModuleLoader(filepath.Dir(parser.file), nil)
+	}
+	loader := parser.modules
+	if loader.generics == nil {
+		loader.generics = parser.generics
+	}
+	// the file which is parsed first is the root of all import chains
+	if len(loader.loading) == 0 {
+		file := absolutePath(parser.file)
+		root := &module{path: strings.TrimSuffix(filepath.Base(file), ".vg"), files: []string{file}}
+		loader.loading = append(loader.loading, root)
+		defer func() {
+			loader.loading = loader.loading[:0]
+		}()
+	}
+	files, ok := loader.resolve(importPath)
+	if !ok {
+		return parser.moduleError(moduleNotFound, "Cannot find module %v")
+	}
+	if chain := loader.cycle(importPath, files); chain != nil {
+		errMsg := fmt.Sprintf("Import cycle not allowed: %v", strings.Join(chain, " -> "))
+		return parser.newParserSyntaxError(importCycle, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+	}
+	m, parsed, err := loader.load(importPath, files)
+	if err != nil {
+		return err
+	}
+	if parsed {
+		parser.warnings = append(parser.warnings, m.warnings...)
+	}
+	parser.imports[name] = m
+	return parser.parseDelimiter()
+}
+
+// parseQualifiedIdentifier parses the reference to a declaration of an imported module, the module name is the
+// current token
+//
+// qualifiedIdentifier
+//   : ID DOT ID
+//   ;
+func (parser *parser) parseQualifiedIdentifier(imported *module) (*utils.Symbol, error) {
+	moduleName := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	if !parser.matchToken(tokens.DOT) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '.'")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	symbol, ok := imported.table.Lookup(parser.currentToken.GetToken().(tokens.IWord).GetLexeme())
+	if !ok {
+		return nil, parser.moduleError(undefinedName, fmt.Sprintf("Undefined name '%v.%%v'", moduleName))
+	}
+	if !exported(parser.currentToken.GetToken().(tokens.IWord).GetLexeme()) {
+		return nil, parser.moduleError(undefinedName, fmt.Sprintf("Cannot refer to unexported name '%v.%%v'", moduleName))
+	}
+	return symbol, nil
+}
@@ -3,6 +3,8 @@ package frontend
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"govega/vega/frontend/utils"
 	"govega/vega/language"
@@ -14,48 +16,49 @@ import (
 // parser stores needed objects to keep track during the parsing
 type parser struct {
 	*vega
-	lexer              Lexer
-	lexicalError       error
-	lineBreakDelimiter bool               // flag to disrupt line break skipping for delimiter character
-	nextToken          *lexicalToken      // next token read by looking a head
-	currentToken       *lexicalToken      // current token which is being analyzed
-	table              *utils.SymbolTable // symbolTable to store information about recognized identifiers
+	lexer        Lexer
+	lexicalError error
+	nextToken    *lexicalToken                  // next token read by looking a head
+	peekedToken  *lexicalToken                  // token after the next token, only read by peekAHead
+	currentToken *lexicalToken                  // current token which is being analyzed
+	table        *utils.SymbolTable             // symbolTable to store information about recognized identifiers
+	types        map[string]language.IBasicType // user-defined types declared at top level
+	imports      map[string]*module             // imported modules by the name used to qualify their declarations
+	globals      *initialization                // top level declarations of the package
+	declaration  *topLevelDeclaration           // top level declaration whose initializer or body is parsed
+	returnType   language.IBasicType            // return type of the function whose body is parsed
+	closure      *closure                       // function literal whose body is parsed
+	closures     []*closure                     // all function literals of the file
+	generic      *generic                       // generic function whose body is parsed
+	generics     *generics                      // generic functions of the project and their instances
+	frameRef     *lexicalToken                  // last token of the last parsed expression referring to a local variable
+	frameRefs    map[*utils.Symbol]bool         // local variables holding a reference to a local variable
+	flow         controlFlow                    // how the last parsed statement completes
+	targets      []*jumpTarget                  // enclosing loops and switches of the current statement
+	warnings     []IVError                      // problems which do not stop the parsing, e.g. unreachable code
 }
 
 // NewParser generates a new Parser interface
 func (v *vega) NewParser(lexer Lexer) Parser {
 	var parser Parser = &parser{
-		vega:               v,
-		lexer:              lexer,
-		lineBreakDelimiter: false,
-		currentToken:       nil,
-		lexicalError:       nil,
-		nextToken:          nil,
-		table:              utils.NewSymbolTable(),
+		vega:         v,
+		lexer:        lexer,
+		currentToken: nil,
+		lexicalError: nil,
+		nextToken:    nil,
+		table:        newPackageTable(),
+		types:        map[string]language.IBasicType{},
+		imports:      map[string]*module{},
+		globals:      &initialization{},
+		generics:     &generics{functions: map[*utils.Symbol]*generic{}},
+		frameRefs:    map[*utils.Symbol]bool{},
 	}
 	return parser
 }
 
-// getToken gets token from lexer
+// getToken gets token from lexer. Line breaks are only returned by the lexer when they terminate a statement.
 func (parser *parser) getToken() (*lexicalToken, error) {
-	var (
-		err   error
-		token *lexicalToken
-	)
-	for {
-		if token, err = parser.lexer.scan(); err != nil {
-			return nil, err
-		}
-		if !parser.lineBreakDelimiter {
-			if token.GetTag() != tokens.LINEBREAK {
-				break
-			}
-		} else {
-			parser.lineBreakDelimiter = false
-			break
-		}
-	}
-	return token, nil
+	return parser.lexer.scan()
 }
 
 // readToken retrieves new tokens from lexer. First the current token is being updated with the previous next token and
@@ -74,6 +77,10 @@ func (parser *parser) readToken() error {
 	} else {
 		parser.currentToken = parser.nextToken
 	}
+	if parser.peekedToken != nil {
+		parser.nextToken, parser.peekedToken = parser.peekedToken, nil
+		return nil
+	}
 	if parser.nextToken, err = parser.getToken(); err != nil {
 		return err
 	}
@@ -85,6 +92,36 @@ func (parser *parser) lookAHead(tag int) bool {
 	return parser.nextToken.GetTag() == tag
 }
 
+// peekAHead compares a given tag with the token after the next token, it is needed where the next token alone does not
+// decide between two rules, e.g. named and positional arguments
+func (parser *parser) peekAHead(tag int) bool {
+	if parser.peekedToken == nil {
+		token, err := parser.getToken()
+		if err != nil {
+			parser.lexicalError = err
+			return false
+		}
+		parser.peekedToken = token
+	}
+	return parser.peekedToken.GetTag() == tag
+}
+
+// lookAHeadType checks if the next token starts a variable type, user-defined types are identifiers which have been
+// declared as type before
+func (parser *parser) lookAHeadType() bool {
+	if parser.lookAHead(tokens.BASIC) || parser.lookAHead(tokens.TYPE) || parser.lookAHead(tokens.FUNC) {
+		return true
+	}
+	if parser.lookAHead(tokens.MULT) {
+		return parser.lookAHeadPointerType()
+	}
+	if parser.lookAHead(tokens.ID) {
+		_, ok := parser.types[parser.nextToken.GetToken().(tokens.IWord).GetLexeme()]
+		return ok
+	}
+	return false
+}
+
 // matchToken compares a given token with the currentToken
 func (parser *parser) matchToken(tag int) bool {
 	if err := parser.readToken(); err != nil {
@@ -105,125 +142,567 @@ func (parser *parser) syntaxError(errorMessage string) error {
 	if parser.currentToken.GetTag() == tokens.EOF {
 		return parser.newParserSyntaxError(unexpectedEOF, parser.currentToken, "Unexpected End Of File", parser.lexer.getLineFeed())
 	}
-	errMsg := fmt.Sprintf(errorMessage, parser.currentToken.GetToken().String())
+	input := parser.currentToken.GetToken().String()
+	// a line break is named like in the expected alternatives instead of being printed
+	if parser.currentToken.GetTag() == tokens.LINEBREAK {
+		errorMessage = strings.Replace(errorMessage, "'%v'", "%v", 1)
+		input = "line break"
+	}
+	errMsg := fmt.Sprintf(errorMessage, input)
 	return parser.newParserSyntaxError(invalidSyntax, parser.currentToken, errMsg, parser.lexer.getLineFeed())
 }
 
+// typeError returns a vega error on mismatching types, the arguments are inserted into the error message and types
+// among them are replaced by their names
+func (parser *parser) typeError(errorMessage string, args ...interface{}) error {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg
+		if t, ok := arg.(language.IBasicType); ok || arg == nil {
+			values[i] = typeName(t)
+		}
+	}
+	errMsg := fmt.Sprintf(errorMessage, values...)
+	return parser.newParserTypeError(typeMismatch, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+}
+
+// operatorError returns a vega error on operands which cannot be combined by the operator, the operator is not part of
+// the format string as it may contain a percent sign
+func (parser *parser) operatorError(operator *lexicalToken, operands ...language.IBasicType) error {
+	errMsg := fmt.Sprintf("Invalid operand '%v' for operator '%v'", typeName(operands[0]), operator.GetToken())
+	if len(operands) == 2 {
+		errMsg = fmt.Sprintf("Invalid operands '%v' and '%v' for operator '%v'", typeName(operands[0]), typeName(operands[1]), operator.GetToken())
+	}
+	return parser.newParserTypeError(typeMismatch, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+}
+
+// narrowingError returns a vega error on values which have to be converted explicitly before they are stored
+func (parser *parser) narrowingError(target language.IBasicType, value language.IBasicType) error {
+	return parser.typeError("Implicit narrowing conversion of '%v' to '%v', use an explicit conversion", value, target)
+}
+
+// storeError checks if a value can be stored in a variable, parameter or result of the target type. Narrowing
+// conversions have to be explicit, every other value has to be assignable to the target.
+func (parser *parser) storeError(target language.IBasicType, value language.IBasicType) error {
+	if narrowing(target, value) {
+		return parser.narrowingError(target, value)
+	}
+	if _, ok := value.(*language.TupleType); ok && !assignable(target, value) {
+		return parser.typeError("Multiple values '%v' in single-value context", value)
+	}
+	if !assignable(target, value) {
+		return parser.typeError("Mismatched type '%v', expected '%v'", value, target)
+	}
+	return nil
+}
+
+// constantError returns a vega error on assignments to a constant or to the elements and fields of a constant
+func (parser *parser) constantError(name string, symbol *utils.Symbol) error {
+	if symbol == nil || !symbol.Const {
+		return nil
+	}
+	errMsg := fmt.Sprintf("Cannot assign to constant '%v'", name)
+	return parser.newParserTypeError(assignmentToConstant, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+}
+
+// warning records a problem at the given token which does not stop the parsing
+func (parser *parser) warning(warningType VErrorType, token *lexicalToken, warningMessage string) {
+	msg := fmt.Sprintf(warningMessage, token.GetToken().String())
+	parser.warnings = append(parser.warnings, parser.newParserWarning(warningType, token, msg, parser.lexer.getLineFeed()))
+}
+
+// controlFlowError returns a vega error on statements which are not allowed at the current position
+func (parser *parser) controlFlowError(errorType VErrorType, errorMessage string) error {
+	errMsg := fmt.Sprintf(errorMessage, parser.currentToken.GetToken().String())
+	return parser.newParserSyntaxError(errorType, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+}
+
 // Parse starts parsing process. All functiones which are validating the grammar are using the Parser interface to make
-// testing easier
+// testing easier. After parsing the initialization order of the global variables is analysed.
 func (parser *parser) Parse(parserInterface Parser) error {
-	return parser.parseBlock(parserInterface)
+	if err := parser.parseBlock(parserInterface); err != nil {
+		return err
+	}
+	return parser.globals.analyze(parser.modules != nil && parser.modules.staticGlobals)
+}
+
+// InitializationOrder returns the names of the global variables in the order they have to be initialized
+func (parser *parser) InitializationOrder() []string {
+	return parser.globals.order
+}
+
+// Warnings returns all warnings found during parsing
+func (parser *parser) Warnings() []IVError {
+	return parser.warnings
+}
+
+// Lookup searches the symbols known to the parser, e.g. to retrieve the documentation of a declared function
+func (parser *parser) Lookup(name string) (*utils.Symbol, bool) {
+	return parser.table.Lookup(name)
 }
 
 // parseBlock parses block statements
 //
 // block
-//   : (FUNC ID LBRACKET functionParamDeclaration? RBRACKET functionReturnType scopeStatement)+ EOF
+//   : importDeclaration* (FUNC ID typeParameters? LBRACKET functionParamDeclaration? RBRACKET functionReturnType scopeStatement | structDeclaration | enumDeclaration | globalDeclaration)+ EOF
 //   ;
 func (parser *parser) parseBlock(parserInterface Parser) error {
+	// the first declaration is parsed before any token has been read
+	if parser.nextToken == nil && parser.lexicalError == nil {
+		var err error
+		if parser.nextToken, err = parser.getToken(); err != nil {
+			return err
+		}
+		// imports are only allowed in front of all other declarations
+		for parser.lookAHead(tokens.IMPORT) {
+			if err := parserInterface.parseImportDeclaration(parserInterface); err != nil {
+				return err
+			}
+		}
+	}
+	if parser.lookAHead(tokens.STRUCT) {
+		if err := parserInterface.parseStructDeclaration(parserInterface); err != nil {
+			return err
+		}
+		return parser.parseNextBlock(parserInterface)
+	}
+	if parser.lookAHead(tokens.ENUM) {
+		if err := parser.parseEnumDeclaration(); err != nil {
+			return err
+		}
+		return parser.parseNextBlock(parserInterface)
+	}
+	if parser.lookAHeadDeclaration() {
+		if err := parserInterface.parseGlobalDeclaration(parserInterface); err != nil {
+			return err
+		}
+		return parser.parseNextBlock(parserInterface)
+	}
 	if !parser.matchToken(tokens.FUNC) {
-		return parser.syntaxError("Missing 'func' at '%v'")
+		return parser.syntaxError("Missing 'func', 'struct' or <declaration> at '%v'")
 	}
+	doc := parser.currentToken.GetDoc()
 	if !parser.matchToken(tokens.ID) {
 		return parser.syntaxError("Mismatched input '%v', expected <identifier>")
 	}
+	name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	declaration, err := parser.declare(false, false)
+	if err != nil {
+		return err
+	}
+	function := utils.NewSymbol(name, nil, true, false)
+	function.Doc = doc
+	parser.table.Add(function)
+	parser.table.NewScope(name)
+	parser.declaration = declaration
+	if parser.lookAHead(tokens.LSBRACKET) {
+		if err := parser.usedBeforeDeclaration(name); err != nil {
+			return err
+		}
+		if function.TypeParams, err = parser.parseTypeParameters(); err != nil {
+			return err
+		}
+		parser.generic = &generic{}
+		parser.generics.functions[function] = parser.generic
+	}
 	if !parser.matchToken(tokens.LBRACKET) {
 		return parser.syntaxError("Mismatched input '%v', expected '('")
 	}
-	if parser.lookAHead(tokens.BASIC) || parser.lookAHead(tokens.TYPE) {
-		if err := parserInterface.parseFunctionParamDeclaration(parserInterface); err != nil {
+	if parser.lookAHeadParameter() {
+		params, err := parserInterface.parseFunctionParamDeclaration(parserInterface)
+		if err != nil {
 			return err
 		}
+		function.Params = paramTypes(params)
+		for _, param := range params {
+			function.ParamNames = append(function.ParamNames, param.name)
+			if param.defaulted {
+				function.Defaults++
+			}
+		}
 	}
 	if !parser.matchToken(tokens.RBRACKET) {
 		return parser.syntaxError("Mismatched input '%v', expected <terminal_variable_type> or ')'")
 	}
-	if err := parserInterface.parseFunctionReturnType(parserInterface); err != nil {
+	if err := parser.unusedTypeParameter(name, function); err != nil {
+		return err
+	}
+	// with type annotations the results follow an arrow like in function types
+	if parser.postfixTypes() && !parser.matchToken(tokens.ARROW) {
+		return parser.syntaxError("Mismatched input '%v', expected '->'")
+	}
+	returnType, err := parserInterface.parseFunctionReturnType(parserInterface)
+	if err != nil {
 		return err
 	}
+	function.SymbolType = returnType
+	parser.returnType = returnType
 	if err := parserInterface.parseScope(parserInterface); err != nil {
 		return err
 	}
-	if parser.lookAHead(tokens.FUNC) {
+	if parser.flow != flowReturn {
+		return parser.controlFlowError(missingReturn, "Missing 'return' at '%v'")
+	}
+	parser.declaration = nil
+	parser.returnType = nil
+	parser.table.LeaveScope()
+	// type parameters are only visible inside the generic function
+	parser.generic = nil
+	for _, typeParam := range function.TypeParams {
+		delete(parser.types, typeParam.GetLexeme())
+	}
+	return parser.parseNextBlock(parserInterface)
+}
+
+// parseNextBlock continues with the next top level declaration or expects the end of file
+func (parser *parser) parseNextBlock(parserInterface Parser) error {
+	if err := parser.parseLineBreak(); err != nil {
+		return err
+	}
+	if parser.lookAHead(tokens.FUNC) || parser.lookAHead(tokens.STRUCT) || parser.lookAHead(tokens.ENUM) || parser.lookAHeadDeclaration() {
 		return parserInterface.parseBlock(parserInterface) // !!! Declaration Stack !!!
 	}
 	if !parser.matchToken(tokens.EOF) {
-		return parser.syntaxError("Extraneous input '%v', expected EOF or 'func'")
+		return parser.syntaxError("Extraneous input '%v', expected EOF, 'func', 'struct' or <declaration>")
 	}
 	return nil
 }
 
-// parseFunctionParamDeclaration parses function parameter list
+// parseGlobalDeclaration parses the declaration of global variables or constants and adds them to the global scope.
+// Constants have to be initialized, the delimiter of the last declaration of a file can be omitted.
 //
-// functionParameterDeclaration
-//   : functionParameterDefinition (COMMA functionParameterDeclaration)*
+// globalDeclaration
+//   : CONST? declarationTargets (ASSIGN values)? delimiter
+//   | ID (COMMA ID)* annotation (ASSIGN values)? delimiter
 //   ;
-func (parser *parser) parseFunctionParamDeclaration(parserInterface Parser) error {
-	if err := parserInterface.parseFunctionParamDefinition(parserInterface); err != nil {
-		return err
+func (parser *parser) parseGlobalDeclaration(parserInterface Parser) error {
+	var targets []*target
+	var err error
+	constant := parser.lookAHead(tokens.CONST)
+	if parser.postfixTypes() {
+		if !parser.matchToken(tokens.ID) {
+			return parser.syntaxError("lexicalError")
+		}
+		if targets, err = parser.parseNames(true); err != nil {
+			return err
+		}
+		if constant, err = parser.parseAnnotation(parserInterface, targets); err != nil {
+			return err
+		}
+	} else {
+		if constant {
+			if !parser.matchToken(tokens.CONST) {
+				return parser.syntaxError("lexicalError")
+			}
+		}
+		if targets, err = parser.parseDeclarationTargets(parserInterface, true, constant); err != nil {
+			return err
+		}
 	}
-	for parser.lookAHead(tokens.COMMA) {
-		if !parser.matchToken(tokens.COMMA) {
+	if parser.lookAHead(tokens.ASSIGN) {
+		if !parser.matchToken(tokens.ASSIGN) {
 			return parser.syntaxError("lexicalError")
 		}
-		if parser.lookAHead(tokens.BASIC) || parser.lookAHead(tokens.TYPE) {
-			if err := parserInterface.parseFunctionParamDeclaration(parserInterface); err != nil {
-				return err
+		// all variables of the declaration depend on the names used by the values
+		initializer := &topLevelDeclaration{}
+		parser.declaration = initializer
+		values, err := parser.parseValues(parserInterface)
+		if err != nil {
+			return err
+		}
+		if err := parser.destructure(targetTypes(targets), values, true); err != nil {
+			return err
+		}
+		for _, declared := range targets {
+			if declared.declaration != nil {
+				declared.declaration.references = initializer.references
+				declared.declaration.dynamic = initializer.dynamic
 			}
-		} else {
+		}
+		parser.declaration = nil
+	} else if constant {
+		_ = parser.matchToken(-1)
+		return parser.syntaxError("Mismatched input '%v', expected '='")
+	}
+	parser.addTargets(targets, constant)
+	if parser.lookAHead(tokens.EOF) {
+		return nil
+	}
+	return parser.parseDelimiter()
+}
+
+// parseStructDeclaration parses a struct declaration and registers the struct as new type. The fields are delimited
+// like statements, the delimiter of the last field can be omitted.
+//
+// structDeclaration
+//   : STRUCT ID LCURLY (variableType ID delimiter)+ RCURLY
+//   | STRUCT ID LCURLY (ID COLON variableType delimiter)+ RCURLY
+//   ;
+func (parser *parser) parseStructDeclaration(parserInterface Parser) error {
+	if !parser.matchToken(tokens.STRUCT) {
+		return parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	if _, ok := parser.types[name]; ok {
+		return parser.syntaxError("Redeclared type '%v'")
+	}
+	if !parser.matchToken(tokens.LCBRACKET) {
+		return parser.syntaxError("Mismatched input '%v', expected '{'")
+	}
+	// the struct is declared in front of its fields, so fields can point to the struct itself
+	structType := language.NewStruct(name, nil)
+	parser.types[name] = structType
+	var fields []language.StructField
+	for {
+		field, err := parser.parseField(parserInterface, fields)
+		if err != nil {
+			return err
+		}
+		// arrays store their elements inside the struct as well
+		if array, ok := field.Type.(*language.ArrayType); field.Type == structType || ok && array.GetType() == structType {
+			return parser.typeError("Invalid recursive field of type '%v', use a pointer", field.Type)
+		}
+		fields = append(fields, field)
+		if parser.lookAHead(tokens.RCBRACKET) {
+			break
+		}
+		if err := parser.parseDelimiter(); err != nil {
+			return err
+		}
+		if parser.lookAHead(tokens.RCBRACKET) {
+			break
+		}
+	}
+	if !parser.matchToken(tokens.RCBRACKET) {
+		return parser.syntaxError("lexicalError")
+	}
+	structType.SetFields(fields)
+	return nil
+}
+
+// parseField parses the name and type of a struct field, the name has to differ from the previously declared fields
+func (parser *parser) parseField(parserInterface Parser, fields []language.StructField) (language.StructField, error) {
+	var field language.StructField
+	if parser.postfixTypes() {
+		if !parser.matchToken(tokens.ID) {
+			return field, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+		}
+	} else {
+		if !parser.lookAHeadType() {
+			_ = parser.matchToken(-1)
+			return field, parser.syntaxError("Mismatched input '%v', expected <variable_type>")
+		}
+		fieldType, err := parserInterface.parseVariableType(parserInterface)
+		if err != nil {
+			return field, err
+		}
+		field.Type = fieldType
+		if !parser.matchToken(tokens.ID) {
+			return field, parser.syntaxError("Mismatched input '%v', expected <identifier> or '['")
+		}
+	}
+	field.Name = parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	for _, declared := range fields {
+		if declared.Name == field.Name {
+			return field, parser.syntaxError("Duplicate field '%v'")
+		}
+	}
+	if parser.postfixTypes() {
+		if !parser.matchToken(tokens.COLON) {
+			return field, parser.syntaxError("Mismatched input '%v', expected ':'")
+		}
+		fieldType, err := parserInterface.parseVariableType(parserInterface)
+		if err != nil {
+			return field, err
+		}
+		field.Type = fieldType
+	}
+	return field, nil
+}
+
+// parameter is a parameter of a function declaration
+type parameter struct {
+	name      string
+	paramType language.IBasicType
+	defaulted bool // the parameter has a default value and can be omitted in calls
+}
+
+// paramTypes returns the types of the parameters in declaration order
+func paramTypes(params []*parameter) []language.IBasicType {
+	types := make([]language.IBasicType, len(params))
+	for i, param := range params {
+		types[i] = param.paramType
+	}
+	return types
+}
+
+// parseFunctionParamDeclaration parses function parameter list, parameters with default values have to be the last
+// parameters of the list
+//
+// functionParameterDeclaration
+//   : functionParameterDefinition (COMMA functionParameterDefinition)*
+//   ;
+func (parser *parser) parseFunctionParamDeclaration(parserInterface Parser) ([]*parameter, error) {
+	var params []*parameter
+	for {
+		param, err := parserInterface.parseFunctionParamDefinition(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		if len(params) > 0 && params[len(params)-1].defaulted && !param.defaulted {
+			return nil, parser.syntaxError("Missing default value for parameter '%v'")
+		}
+		params = append(params, param)
+		if !parser.lookAHead(tokens.COMMA) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		if !parser.lookAHeadParameter() {
 			_ = parser.matchToken(-1)
-			return parser.syntaxError("Mismatched input '%v', expected <terminal_variable_type>")
+			return nil, parser.syntaxError("Mismatched input '%v', expected <terminal_variable_type>")
 		}
 	}
 	// exit here with error when next token is not ')'
 	if !parser.lookAHead(tokens.RBRACKET) {
 		_ = parser.matchToken(-1)
-		return parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
+		return nil, parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
 	}
-	return nil
+	return params, nil
 }
 
-// parseFunctionParamDefinition parse function parameter definition
+// parseFunctionParamDefinition parse function parameter definition and adds the parameter to the function scope. Array
+// parameters are declared without size and passed as slices. Default values have to be constant expressions.
 //
 // functionParameterDefinition
-//   : terminalVariableType (LARRAY RARRAY)* ID
+//   : terminalVariableType (LARRAY RARRAY)* ID (ASSIGN booleanExpression)?
+//   | ID COLON parameterType (ASSIGN booleanExpression)?
 //   ;
-func (parser *parser) parseFunctionParamDefinition(parserInterface Parser) error {
-	if err := parserInterface.parseTerminalVariableType(); err != nil {
-		return err
-	}
-	for parser.lookAHead(tokens.LSBRACKET) {
-		if !parser.matchToken(tokens.LSBRACKET) {
-			return parser.syntaxError("lexicalError")
+func (parser *parser) parseFunctionParamDefinition(parserInterface Parser) (*parameter, error) {
+	param := &parameter{}
+	if parser.postfixTypes() {
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
 		}
-		if !parser.matchToken(tokens.RSBRACKET) {
-			return parser.syntaxError("Extraneous input '%v', expected ']'")
+		param.name = parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+		if !parser.matchToken(tokens.COLON) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected ':'")
+		}
+		paramType, err := parser.parseParameterType(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		param.paramType = paramType
+	} else {
+		paramType, err := parserInterface.parseTerminalVariableType()
+		if err != nil {
+			return nil, err
+		}
+		for parser.lookAHead(tokens.LSBRACKET) {
+			if !parser.matchToken(tokens.LSBRACKET) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+			if !parser.matchToken(tokens.RSBRACKET) {
+				return nil, parser.syntaxError("Extraneous input '%v', expected ']'")
+			}
+			paramType = language.NewSlice(paramType)
+		}
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected '[' or <identifier>")
 		}
+		param.name = parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+		param.paramType = paramType
 	}
-	if !parser.matchToken(tokens.ID) {
-		return parser.syntaxError("Mismatched input '%v', expected '[' or <identifier>")
+	paramType := param.paramType
+	if parser.lookAHead(tokens.ASSIGN) {
+		if !parser.matchToken(tokens.ASSIGN) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		// the default value is recorded like the initializer of a global to check that it is constant
+		enclosing, value := parser.declaration, &topLevelDeclaration{}
+		parser.declaration = value
+		valueType, err := parserInterface.parseBooleanExpression(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		parser.declaration = enclosing
+		if enclosing != nil {
+			enclosing.references = append(enclosing.references, value.references...)
+		}
+		if err := parser.storeError(paramType, valueType); err != nil {
+			return nil, err
+		}
+		if !parser.globals.constantInitializer(value) {
+			errMsg := fmt.Sprintf("Non-constant default value for parameter '%v'", param.name)
+			return nil, parser.newParserSyntaxError(invalidInitializer, parser.currentToken, errMsg, parser.lexer.getLineFeed())
+		}
+		param.defaulted = true
 	}
-	return nil
+	parser.table.Add(utils.NewSymbol(param.name, paramType, false, false))
+	return param, nil
 }
 
-// parseFunctionReturnType parse return type of function and sets the function identifier symbol type
+// parseFunctionReturnType parse return type of function and sets the function identifier symbol type, functions with
+// multiple results return a tuple
 //
 // functionReturnType
+//   : parameterType
+//   | LBRACKET parameterType (COMMA parameterType)* RBRACKET
+//   ;
+func (parser *parser) parseFunctionReturnType(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.lookAHead(tokens.LBRACKET) {
+		return parser.parseParameterType(parserInterface)
+	}
+	if !parser.matchToken(tokens.LBRACKET) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	var results []language.IBasicType
+	for {
+		result, err := parser.parseParameterType(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+		if !parser.lookAHead(tokens.COMMA) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	}
+	if !parser.matchToken(tokens.RBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
+	}
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	return language.NewTuple(results), nil
+}
+
+// parseParameterType parses the type of a parameter or a single result, arrays are passed as slices
+//
+// parameterType
 //   : terminalVariableType (LARRAY RARRAY)*
 //   ;
-func (parser *parser) parseFunctionReturnType(parserInterface Parser) error {
-	if err := parserInterface.parseTerminalVariableType(); err != nil {
-		return err
+func (parser *parser) parseParameterType(parserInterface Parser) (language.IBasicType, error) {
+	returnType, err := parserInterface.parseTerminalVariableType()
+	if err != nil {
+		return nil, err
 	}
 	for parser.lookAHead(tokens.LSBRACKET) {
 		if !parser.matchToken(tokens.LSBRACKET) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
 		if !parser.matchToken(tokens.RSBRACKET) {
-			return parser.syntaxError("Mismatched input '%v', expected ']'")
+			return nil, parser.syntaxError("Mismatched input '%v', expected ']'")
 		}
+		returnType = language.NewSlice(returnType)
 	}
-	return nil
+	return returnType, nil
 }
 
 // parseTerminalVariableType parse basic variable type terminals
@@ -234,51 +713,103 @@ func (parser *parser) parseFunctionReturnType(parserInterface Parser) error {
 //   | CHAR_TYPE
 //   | BOOL_TYPE
 //   | STRING_TYPE
+//   | ID
+//   | functionType
+//   | pointerType
+//   | resultType
 //   ;
-func (parser *parser) parseTerminalVariableType() error {
+func (parser *parser) parseTerminalVariableType() (language.IBasicType, error) {
 	switch {
+	case parser.lookAHead(tokens.FUNC):
+		return parser.parseFunctionType()
+	case parser.lookAHead(tokens.MULT):
+		return parser.parsePointerType()
+	case parser.lookAHeadType() && parser.lookAHead(tokens.ID):
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		return parser.types[parser.currentToken.GetToken().(tokens.IWord).GetLexeme()], nil
 	case parser.lookAHead(tokens.BASIC):
 		if !parser.matchToken(tokens.BASIC) {
-			return parser.syntaxError("lexicalError")
-		}
-		switch parser.currentToken.GetToken().(tokens.IWord).GetLexeme() {
-		case language.IntType.GetLexeme():
-			return nil
-		case language.BoolType.GetLexeme():
-			return nil
-		case language.FloatType.GetLexeme():
-			return nil
-		case language.CharType.GetLexeme():
-			return nil
+			return nil, parser.syntaxError("lexicalError")
 		}
-		return nil
+		// basic types are stored as keywords and can be used directly
+		return parser.currentToken.GetToken().(language.IBasicType), nil
 	case parser.lookAHead(tokens.TYPE):
 		if !parser.matchToken(tokens.TYPE) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
 		switch parser.currentToken.GetToken().(tokens.IWord).GetLexeme() {
 		case "str":
-			return nil
+			return language.NewString(0), nil
+		case "Result":
+			return parser.parseResultType()
 		}
-		return nil
+		return nil, nil
 	default:
 		_ = parser.matchToken(-1)
-		return parser.syntaxError("Mismatched input '%v', expected <variable_type>")
+		return nil, parser.syntaxError("Mismatched input '%v', expected <variable_type>")
 	}
 }
 
-// parseDelimiter parses delimiter characters
+// parseVariableType parses the type of a declared variable or struct field. Slices are declared without size in front
+// of the array dimensions, the sizes of arrays have to be defined.
 //
-// delimiter
-//   : delimiterCharacters
+// variableType
+//   : terminalVariableType (LARRAY RARRAY)* (LARRAY INT RARRAY)*
 //   ;
-func (parser *parser) parseDelimiter() error {
-	switch {
-	case parser.lookAHead(tokens.DELIMITER):
-		if !parser.matchToken(tokens.DELIMITER) {
-			return parser.syntaxError("lexicalError")
-		}
-	case parser.lookAHead(tokens.LINEBREAK):
+func (parser *parser) parseVariableType(parserInterface Parser) (language.IBasicType, error) {
+	varType, err := parserInterface.parseTerminalVariableType()
+	if err != nil {
+		return nil, err
+	}
+	slices := 0
+	var sizes []int
+	for parser.lookAHead(tokens.LSBRACKET) {
+		if !parser.matchToken(tokens.LSBRACKET) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		if sizes == nil && parser.lookAHead(tokens.RSBRACKET) {
+			if !parser.matchToken(tokens.RSBRACKET) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+			slices++
+			continue
+		}
+		if !parser.matchToken(tokens.NUM) {
+			if sizes == nil {
+				return nil, parser.syntaxError("Mismatched input '%v', expected <INT> or ']'")
+			}
+			return nil, parser.syntaxError("Mismatched input '%v', expected <INT>")
+		}
+		sizes = append(sizes, parser.currentToken.GetToken().(tokens.INum).GetValue())
+		if !parser.matchToken(tokens.RSBRACKET) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected ']'")
+		}
+	}
+	// int[2][3] is an array of two elements which are arrays of three integers
+	for i := len(sizes) - 1; i >= 0 && varType != nil; i-- {
+		varType = arrayOf(varType, sizes[i])
+	}
+	// int[][3] is a slice of arrays of three integers
+	for ; slices > 0 && varType != nil; slices-- {
+		varType = language.NewSlice(varType)
+	}
+	return varType, nil
+}
+
+// parseDelimiter parses delimiter characters
+//
+// delimiter
+//   : delimiterCharacters
+//   ;
+func (parser *parser) parseDelimiter() error {
+	switch {
+	case parser.lookAHead(tokens.DELIMITER):
+		if !parser.matchToken(tokens.DELIMITER) {
+			return parser.syntaxError("lexicalError")
+		}
+	case parser.lookAHead(tokens.LINEBREAK):
 		if !parser.matchToken(tokens.LINEBREAK) {
 			return parser.syntaxError("lexicalError")
 		}
@@ -289,6 +820,17 @@ func (parser *parser) parseDelimiter() error {
 	return nil
 }
 
+// parseLineBreak skips the line break following a closing curly bracket. The lexer converts this line break into a
+// delimiter, but statements ending with a scope do not need to be delimited.
+func (parser *parser) parseLineBreak() error {
+	if parser.lookAHead(tokens.LINEBREAK) {
+		if !parser.matchToken(tokens.LINEBREAK) {
+			return parser.syntaxError("lexicalError")
+		}
+	}
+	return nil
+}
+
 // parseScope parses scopes
 //
 // scopeStatement
@@ -298,9 +840,10 @@ func (parser *parser) parseScope(parserInterface Parser) error {
 	if !parser.matchToken(tokens.LCBRACKET) {
 		return parser.syntaxError("Mismatched input '%v', expected '{'")
 	}
+	parser.table.NewScope("scope")
+	var sequence statementSequence
 	// statement: PASS delimiter
 	if parser.lookAHead(tokens.PASS) {
-		parser.lineBreakDelimiter = true
 		if !parser.matchToken(tokens.PASS) {
 			return parser.syntaxError("lexicalError")
 		}
@@ -314,7 +857,7 @@ func (parser *parser) parseScope(parserInterface Parser) error {
 			return parser.syntaxError("Mismatched input '%v', expected 'pass;' or <statement>")
 		}
 		// at least one statement has to be defined
-		if err := parserInterface.parseStatement(parserInterface); err != nil {
+		if err := parser.parseSequenceStatement(parserInterface, &sequence); err != nil {
 			if err.Error() == "StatementNotDefined" {
 				_ = parser.matchToken(-1)
 				return parser.syntaxError("Mismatched input '%v', expected 'pass;' or <statement>")
@@ -322,7 +865,7 @@ func (parser *parser) parseScope(parserInterface Parser) error {
 			return err
 		}
 		for !parser.lookAHead(tokens.RCBRACKET) {
-			if err := parserInterface.parseStatement(parserInterface); err != nil {
+			if err := parser.parseSequenceStatement(parserInterface, &sequence); err != nil {
 				if err.Error() == "StatementNotDefined" {
 					_ = parser.matchToken(-1)
 					return parser.syntaxError("Mismatched input '%v', expected <statement> or '}'")
@@ -334,39 +877,66 @@ func (parser *parser) parseScope(parserInterface Parser) error {
 	if !parser.matchToken(tokens.RCBRACKET) {
 		return parser.syntaxError("Mismatched input '%v', expected '}'")
 	}
+	parser.table.LeaveScope()
+	parser.flow = sequence.flow
+	return nil
+}
+
+// parseSequenceStatement parses the next statement of a scope or switch case and updates the control flow of the
+// sequence. Statements following a statement which does not complete normally can never be executed.
+func (parser *parser) parseSequenceStatement(parserInterface Parser, sequence *statementSequence) error {
+	if sequence.flow != flowNormal && !sequence.unreachable {
+		sequence.unreachable = true
+		parser.warning(unreachableCode, parser.nextToken, "Unreachable statement '%v'")
+	}
+	if err := parserInterface.parseStatement(parserInterface); err != nil {
+		return err
+	}
+	if sequence.flow == flowNormal {
+		sequence.flow = parser.flow
+	}
 	return nil
 }
 
 // parseStatement parses normal statements
 //
 // statement
-//   : CONST? terminalVariableType (LARRAY INT RARRAY)* ID (ASSIGN booleanExpression)? DELIMITER
-//   |  ID (
-//  		(LBRACKET ( booleanExpression (COMMA booleanExpression)* )? RBRACKET)
-//  	  | (arrayAccess* ASSIGN booleanExpression)
-// 	    ) delimiter
+//   : declaration delimiter
+//   |  inferredDeclaration delimiter
+//   |  assignmentOrCall delimiter
+//   |  dereferenceAssignment delimiter
+//   |  try delimiter
 //   |  RETURN booleanExpression delimiter
 //   |  CONTINUE delimiter
 //   |  BREAK delimiter
 //   |  WHILE conditionalScope
+//   |  forStatement
 //   |  IF conditionalScope (ELIF conditionalScope)* (ELSE scopeStatement)?
-//   |  SWITCH booleanExpression LCURLY (CASE terminal COLON statement+)+ (DEFAULT COLON statement+)? RCURLY
+//   |  switchStatement
 // ;
 func (parser *parser) parseStatement(parserInterface Parser) error {
+	parser.flow = flowNormal
 	switch {
 	// statement: CONTINUE delimiter
 	case parser.lookAHead(tokens.CONTINUE):
-		parser.lineBreakDelimiter = true
 		if !parser.matchToken(tokens.CONTINUE) {
 			return parser.syntaxError("lexicalError")
 		}
+		if !parser.insideLoop() {
+			return parser.controlFlowError(invalidControlFlow, "Unexpected '%v' outside of loop")
+		}
+		parser.flow = flowJump
 		return parser.parseDelimiter()
 	// statement: BREAK delimiter
 	case parser.lookAHead(tokens.BREAK):
-		parser.lineBreakDelimiter = true
 		if !parser.matchToken(tokens.BREAK) {
 			return parser.syntaxError("lexicalError")
 		}
+		if len(parser.targets) == 0 {
+			return parser.controlFlowError(invalidControlFlow, "Unexpected '%v' outside of loop or switch")
+		}
+		parser.targets[len(parser.targets)-1].broken = true
+		parser.flow = flowJump
 		return parser.parseDelimiter()
 	// statement: IF conditionalScope (ELIF conditionalScope)* (ELSE scopeStatement)?
 	case parser.lookAHead(tokens.IF):
@@ -376,6 +946,7 @@ func (parser *parser) parseStatement(parserInterface Parser) error {
 		if err := parserInterface.parseConditionalScope(parserInterface); err != nil {
 			return err
 		}
+		branches := []controlFlow{parser.flow}
 		for parser.lookAHead(tokens.ELIF) {
 			if !parser.matchToken(tokens.ELIF) {
 				return parser.syntaxError("lexicalError")
@@ -383,7 +954,9 @@ func (parser *parser) parseStatement(parserInterface Parser) error {
 			if err := parserInterface.parseConditionalScope(parserInterface); err != nil {
 				return err
 			}
+			branches = append(branches, parser.flow)
 		}
+		// without else branch the execution continues after the if statement when no condition is true
 		if parser.lookAHead(tokens.ELSE) {
 			if !parser.matchToken(tokens.ELSE) {
 				return parser.syntaxError("lexicalError")
@@ -391,194 +964,88 @@ func (parser *parser) parseStatement(parserInterface Parser) error {
 			if err := parserInterface.parseScope(parserInterface); err != nil {
 				return err
 			}
+			branches = append(branches, parser.flow)
+		} else {
+			branches = append(branches, flowNormal)
 		}
-		return nil
-	// statement: SWITCH expression LCURLY (CASE terminal COLON statement+)+ (DEFAULT COLON statement+)? RCURLY
+		parser.flow = combineFlow(branches...)
+		return parser.parseLineBreak()
+	// statement: switchStatement
 	case parser.lookAHead(tokens.SWITCH):
-		if !parser.matchToken(tokens.SWITCH) {
-			return parser.syntaxError("lexicalError")
-		}
-		if err := parserInterface.parseExpression(parserInterface); err != nil {
-			return err
-		}
-		if !parser.matchToken(tokens.LCBRACKET) {
-			return parser.syntaxError("Mismatched input '%v', expected '{'")
-		}
-		if !parser.matchToken(tokens.CASE) {
-			return parser.syntaxError("Mismatched input '%v', expected 'case' or 'default'")
-		}
-		if err := parserInterface.parseTerminal(); err != nil {
-			return err
-		}
-		if !parser.matchToken(tokens.COLON) {
-			return parser.syntaxError("Mismatched input '%v', expected ':'")
-		}
-		if err := parserInterface.parseStatement(parserInterface); err != nil {
-			if err.Error() == "StatementNotDefined" {
-				_ = parser.matchToken(-1)
-				return parser.syntaxError("Mismatched input '%v', expected <statement>")
-			}
+		if err := parserInterface.parseSwitchStatement(parserInterface); err != nil {
 			return err
 		}
-		for !parser.lookAHead(tokens.RCBRACKET) && !parser.lookAHead(tokens.CASE) && !parser.lookAHead(tokens.DEFAULT) {
-			if err := parserInterface.parseStatement(parserInterface); err != nil {
-				if err.Error() == "StatementNotDefined" {
-					_ = parser.matchToken(-1)
-					return parser.syntaxError("Mismatched input '%v', expected <statement>, another 'case' or 'default' keyword or '}'")
-				}
-				return err
-			}
-		}
-		for parser.lookAHead(tokens.CASE) {
-			if !parser.matchToken(tokens.CASE) {
-				return parser.syntaxError("lexicalError")
-			}
-			if err := parserInterface.parseTerminal(); err != nil {
-				return err
-			}
-			if !parser.matchToken(tokens.COLON) {
-				return parser.syntaxError("Mismatched input '%v', expected ':'")
-			}
-			if err := parserInterface.parseStatement(parserInterface); err != nil {
-				if err.Error() == "StatementNotDefined" {
-					_ = parser.matchToken(-1)
-					return parser.syntaxError("Mismatched input '%v', expected <statement>")
-				}
-				return err
-			}
-			for !parser.lookAHead(tokens.RCBRACKET) && !parser.lookAHead(tokens.CASE) && !parser.lookAHead(tokens.DEFAULT) {
-				if err := parserInterface.parseStatement(parserInterface); err != nil {
-					if err.Error() == "StatementNotDefined" {
-						_ = parser.matchToken(-1)
-						return parser.syntaxError("Mismatched input '%v', expected <statement>, another 'case' or 'default' keyword or '}'")
-					}
-					return err
-				}
-			}
-		}
-		if parser.lookAHead(tokens.DEFAULT) {
-			if !parser.matchToken(tokens.DEFAULT) {
-				return parser.syntaxError("lexicalError")
-			}
-			if !parser.matchToken(tokens.COLON) {
-				return parser.syntaxError("Mismatched input '%v', expected ':'")
-			}
-			if err := parserInterface.parseStatement(parserInterface); err != nil {
-				if err.Error() == "StatementNotDefined" {
-					_ = parser.matchToken(-1)
-					return parser.syntaxError("Mismatched input '%v', expected <statement>")
-				}
-				return err
-			}
-			for !parser.lookAHead(tokens.RCBRACKET) {
-				if err := parserInterface.parseStatement(parserInterface); err != nil {
-					if err.Error() == "StatementNotDefined" {
-						_ = parser.matchToken(-1)
-						return parser.syntaxError("Mismatched input '%v', expected <statement> or '}'")
-					}
-					return err
-				}
-			}
-		}
-		if !parser.matchToken(tokens.RCBRACKET) {
-			return parser.syntaxError("lexicalError")
-		}
-		return nil
+		return parser.parseLineBreak()
+	// fallthrough is only allowed as last statement of a case and handled by parseCaseBody
+	case parser.lookAHead(tokens.FALLTHROUGH):
+		_ = parser.matchToken(tokens.FALLTHROUGH)
+		return parser.controlFlowError(invalidControlFlow, "Unexpected '%v' outside of switch case")
 	// statement: WHILE conditionalScope
 	case parser.lookAHead(tokens.WHILE):
 		if !parser.matchToken(tokens.WHILE) {
 			return parser.syntaxError("lexicalError")
 		}
-		return parserInterface.parseConditionalScope(parserInterface)
-	// statement: RETURN booleanExpression delimiter
+		parser.enterJumpTarget(true)
+		if err := parserInterface.parseConditionalScope(parserInterface); err != nil {
+			return err
+		}
+		parser.leaveJumpTarget()
+		parser.flow = flowNormal
+		return parser.parseLineBreak()
+	// statement: forStatement
+	case parser.lookAHead(tokens.FOR):
+		if err := parserInterface.parseForStatement(parserInterface); err != nil {
+			return err
+		}
+		return parser.parseLineBreak()
+	// statement: RETURN values delimiter
 	case parser.lookAHead(tokens.RETURN):
 		if !parser.matchToken(tokens.RETURN) {
 			return parser.syntaxError("lexicalError")
 		}
-		if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
+		parser.flow = flowReturn
+		values, err := parser.parseValues(parserInterface)
+		if err != nil {
 			return err
 		}
-		return parser.parseDelimiter()
-	// CONST? terminalVariableType (LARRAY INT RARRAY)* ID (ASSIGN booleanExpression)? delimiter
-	case parser.lookAHead(tokens.CONST), parser.lookAHead(tokens.BASIC), parser.lookAHead(tokens.TYPE):
-		if parser.lookAHead(tokens.CONST) {
-			if !parser.matchToken(tokens.CONST) {
-				return parser.syntaxError("lexicalError")
-			}
+		if err := parser.returnError(values); err != nil {
+			return err
+		}
+		if parser.referencesFrame() {
+			return parser.escapeError("Cannot return reference to local variable")
 		}
-		if err := parserInterface.parseTerminalVariableType(); err != nil {
+		return parser.parseDelimiter()
+	// try delimiter, the value of a successful result is discarded
+	case parser.lookAHead(tokens.TRY):
+		if _, err := parser.parseTry(parserInterface); err != nil {
 			return err
 		}
-		for parser.lookAHead(tokens.LSBRACKET) {
-			if !parser.matchToken(tokens.LSBRACKET) {
-				return parser.syntaxError("lexicalError")
-			}
-			if !parser.matchToken(tokens.NUM) {
-				return parser.syntaxError("Mismatched input '%v', expected <INT>")
-			}
-			if !parser.matchToken(tokens.RSBRACKET) {
-				return parser.syntaxError("Mismatched input '%v', expected ']'")
-			}
+		return parser.parseDelimiter()
+	// inferredDeclaration delimiter
+	case parser.lookAHeadInferredDeclaration():
+		if err := parser.parseInferredDeclaration(parserInterface); err != nil {
+			return err
 		}
-		parser.lineBreakDelimiter = true
-		if !parser.matchToken(tokens.ID) {
-			return parser.syntaxError("Mismatched input '%v', expected <identifier> or '['")
+		return parser.parseDelimiter()
+	// dereferenceAssignment delimiter, pointers to pointers are declared with var
+	case parser.lookAHead(tokens.MULT) && (parser.postfixTypes() || parser.peekAHead(tokens.MULT) || !parser.lookAHeadType()):
+		if err := parser.parseDereferenceAssignment(parserInterface); err != nil {
+			return err
 		}
-		if parser.lookAHead(tokens.ASSIGN) {
-			if !parser.matchToken(tokens.ASSIGN) {
-				return parser.syntaxError("lexicalError")
-			}
-			if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-				return err
-			}
+		return parser.parseDelimiter()
+	// declaration delimiter
+	case !parser.postfixTypes() && (parser.lookAHead(tokens.CONST) || parser.lookAHeadType()):
+		if err := parserInterface.parseDeclaration(parserInterface); err != nil {
+			return err
 		}
 		return parser.parseDelimiter()
-	// ID ((LBRACKET ( booleanExpression (COMMA booleanExpression)* )? RBRACKET) | (arrayAccess* ASSIGN booleanExpression)) delimiter
+	// (assignmentOrCall | annotatedDeclaration) delimiter
 	case parser.lookAHead(tokens.ID):
 		if !parser.matchToken(tokens.ID) {
 			return parser.syntaxError("lexicalError")
 		}
-		switch {
-		// function call
-		case parser.lookAHead(tokens.LBRACKET):
-			if !parser.matchToken(tokens.LBRACKET) {
-				return parser.syntaxError("lexicalError")
-			}
-			if !parser.lookAHead(tokens.RBRACKET) {
-				if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-					return err
-				}
-				for parser.lookAHead(tokens.COMMA) {
-					if !parser.matchToken(tokens.COMMA) {
-						return parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
-					}
-					if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-						return err
-					}
-				}
-			}
-			parser.lineBreakDelimiter = true
-			if !parser.matchToken(tokens.RBRACKET) {
-				return parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
-			}
-		// array definiton
-		case parser.lookAHead(tokens.LSBRACKET):
-			for parser.lookAHead(tokens.LSBRACKET) {
-				if err := parserInterface.parseArrayAccess(parserInterface); err != nil {
-					return err
-				}
-			}
-			fallthrough
-		case parser.lookAHead(tokens.ASSIGN):
-			if !parser.matchToken(tokens.ASSIGN) {
-				return parser.syntaxError("Mismatched input '%v', expected '[', ',' or '='")
-			}
-			if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-				return err
-			}
-		default:
-			_ = parser.matchToken(-1)
-			return parser.syntaxError("Mismatched input '%v', expected '(', '[', ',' or '='")
+		if err := parser.parseDeclarationOrAssignment(parserInterface); err != nil {
+			return err
 		}
 		return parser.parseDelimiter()
 	default:
@@ -586,136 +1053,743 @@ func (parser *parser) parseStatement(parserInterface Parser) error {
 	}
 }
 
-// conditionalScope
-//   : booleanExpression scopeStatement
+// parseSwitchStatement parses switch statements. The case values have to match the type of the switch expression and
+// must be unique. The execution does not continue with the next case unless the case ends with fallthrough. A switch on
+// an enum without default case has to handle all variants.
+//
+// switchStatement
+//   : SWITCH expression LCURLY (CASE caseValue (COMMA caseValue)* COLON caseBody)+ (DEFAULT COLON caseBody)? RCURLY
 //   ;
-func (parser *parser) parseConditionalScope(parserInterface Parser) error {
-	if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-		return err
+func (parser *parser) parseSwitchStatement(parserInterface Parser) error {
+	if !parser.matchToken(tokens.SWITCH) {
+		return parser.syntaxError("lexicalError")
 	}
-	return parserInterface.parseScope(parserInterface)
-}
-
-// booleanExpression
-//   :   comparisonExpression ((OR | AND) comparisonExpression)*
-//   ;
-func (parser *parser) parseBooleanExpression(parserInterface Parser) error {
-	if err := parserInterface.parseComparisonExpression(parserInterface); err != nil {
+	switchToken := parser.currentToken
+	switchType, err := parserInterface.parseExpression(parserInterface)
+	if err != nil {
 		return err
 	}
-	var loopControl = false
-	for {
-		switch {
-		case parser.lookAHead(tokens.OR):
-			if !parser.matchToken(tokens.OR) {
-				return parser.syntaxError("lexicalError")
+	if !parser.matchToken(tokens.LCBRACKET) {
+		return parser.syntaxError("Mismatched input '%v', expected '{'")
+	}
+	if !parser.lookAHead(tokens.CASE) {
+		_ = parser.matchToken(-1)
+		return parser.syntaxError("Mismatched input '%v', expected 'case' or 'default'")
+	}
+	parser.enterJumpTarget(false)
+	values := map[string]bool{}
+	var cases []controlFlow
+	var fallsThrough []bool
+	for parser.lookAHead(tokens.CASE) {
+		if !parser.matchToken(tokens.CASE) {
+			return parser.syntaxError("lexicalError")
+		}
+		for {
+			valueType, err := parser.parseCaseValue(parserInterface)
+			if err != nil {
+				return err
 			}
-		case parser.lookAHead(tokens.BOOLOR):
-			if !parser.matchToken(tokens.BOOLOR) {
-				return parser.syntaxError("lexicalError")
+			if !comparableTypes(switchType, valueType) {
+				return parser.typeError("Mismatched type '%v' in case, expected '%v'", valueType, switchType)
 			}
-		case parser.lookAHead(tokens.AND):
-			if !parser.matchToken(tokens.AND) {
-				return parser.syntaxError("lexicalError")
+			value := caseValue(parser.currentToken.GetToken())
+			if values[value] {
+				return parser.syntaxError("Duplicate case '%v'")
 			}
-		case parser.lookAHead(tokens.BOOLAND):
-			if !parser.matchToken(tokens.BOOLAND) {
+			values[value] = true
+			if !parser.lookAHead(tokens.COMMA) {
+				break
+			}
+			if !parser.matchToken(tokens.COMMA) {
 				return parser.syntaxError("lexicalError")
 			}
-		default:
-			loopControl = true
 		}
-		if loopControl {
-			break
+		if !parser.matchToken(tokens.COLON) {
+			return parser.syntaxError("Mismatched input '%v', expected ',' or ':'")
+		}
+		flow, next, err := parser.parseCaseBody(parserInterface, false)
+		if err != nil {
+			return err
+		}
+		cases = append(cases, flow)
+		fallsThrough = append(fallsThrough, next)
+	}
+	exhaustive := parser.lookAHead(tokens.DEFAULT)
+	if exhaustive {
+		if !parser.matchToken(tokens.DEFAULT) {
+			return parser.syntaxError("lexicalError")
+		}
+		if !parser.matchToken(tokens.COLON) {
+			return parser.syntaxError("Mismatched input '%v', expected ':'")
+		}
+		flow, _, err := parser.parseCaseBody(parserInterface, true)
+		if err != nil {
+			return err
+		}
+		cases = append(cases, flow)
+		fallsThrough = append(fallsThrough, false)
+	} else if switchType == language.BoolType {
+		exhaustive = values["true"] && values["false"]
+		for _, value := range []string{"true", "false"} {
+			if !values[value] {
+				parser.warning(nonExhaustiveSwitch, switchToken, fmt.Sprintf("Missing case '%v' in '%%v' on bool without default", value))
+			}
 		}
-		if err := parserInterface.parseComparisonExpression(parserInterface); err != nil {
+	} else if enum, ok := switchType.(*language.EnumType); ok {
+		if err := parser.enumSwitchError(enum, values, switchToken); err != nil {
 			return err
 		}
+		exhaustive = true
+	}
+	if !parser.matchToken(tokens.RCBRACKET) {
+		return parser.syntaxError("lexicalError")
+	}
+	// a case ending with fallthrough completes like the following case
+	for i := len(cases) - 2; i >= 0; i-- {
+		if fallsThrough[i] {
+			cases[i] = cases[i+1]
+		}
+	}
+	// without default case the execution continues after the switch when no case matches and a break inside the switch
+	// continues the execution after the switch as well
+	if parser.leaveJumpTarget().broken || !exhaustive {
+		parser.flow = flowNormal
+	} else {
+		parser.flow = combineFlow(cases...)
 	}
 	return nil
 }
 
-// comparisonExpression
-// :   expression (comparisonOperator expression)*
-// ;
-func (parser *parser) parseComparisonExpression(parserInterface Parser) error {
-	if err := parserInterface.parseExpression(parserInterface); err != nil {
-		return err
-	}
-	var loopControl = false
-	for {
-		switch {
-		case parser.lookAHead(tokens.EQ):
-			if !parser.matchToken(tokens.EQ) {
-				return parser.syntaxError("lexicalError")
-			}
-		case parser.lookAHead(tokens.NE):
-			if !parser.matchToken(tokens.NE) {
-				return parser.syntaxError("lexicalError")
-			}
-		case parser.lookAHead(tokens.GE):
-			if !parser.matchToken(tokens.GE) {
-				return parser.syntaxError("lexicalError")
+// parseCaseBody parses the statements of a case and reports if the case ends with fallthrough
+//
+// caseBody
+//   : statement+ (FALLTHROUGH delimiter)?
+//   | FALLTHROUGH delimiter
+//   ;
+func (parser *parser) parseCaseBody(parserInterface Parser, defaultCase bool) (controlFlow, bool, error) {
+	var sequence statementSequence
+	for first := true; ; first = false {
+		if !first && (parser.lookAHead(tokens.RCBRACKET) || !defaultCase && (parser.lookAHead(tokens.CASE) || parser.lookAHead(tokens.DEFAULT))) {
+			return sequence.flow, false, nil
+		}
+		if parser.lookAHead(tokens.FALLTHROUGH) {
+			if !parser.matchToken(tokens.FALLTHROUGH) {
+				return flowNormal, false, parser.syntaxError("lexicalError")
 			}
-		case parser.lookAHead(tokens.GREATER):
-			if !parser.matchToken(tokens.GREATER) {
-				return parser.syntaxError("lexicalError")
+			fallthroughToken := parser.currentToken
+			if defaultCase {
+				return flowNormal, false, parser.controlFlowError(invalidControlFlow, "Unexpected '%v' in last case of switch")
 			}
-		case parser.lookAHead(tokens.LE):
-			if !parser.matchToken(tokens.LE) {
-				return parser.syntaxError("lexicalError")
+			if err := parser.parseDelimiter(); err != nil {
+				return flowNormal, false, err
 			}
-		case parser.lookAHead(tokens.LESS):
-			if !parser.matchToken(tokens.LESS) {
-				return parser.syntaxError("lexicalError")
+			if !parser.lookAHead(tokens.CASE) && !parser.lookAHead(tokens.DEFAULT) {
+				_ = parser.matchToken(-1)
+				if parser.currentToken.GetTag() == tokens.RCBRACKET {
+					return flowNormal, false, parser.newParserSyntaxError(invalidControlFlow, fallthroughToken,
+						"Unexpected 'fallthrough' in last case of switch", parser.lexer.getLineFeed())
+				}
+				return flowNormal, false, parser.syntaxError("Mismatched input '%v', expected 'case' or 'default' after 'fallthrough'")
 			}
-		default:
-			loopControl = true
-		}
-		if loopControl {
-			break
+			return sequence.flow, true, nil
 		}
-		if err := parserInterface.parseExpression(parserInterface); err != nil {
-			return err
+		if err := parser.parseSequenceStatement(parserInterface, &sequence); err != nil {
+			if err.Error() == "StatementNotDefined" {
+				_ = parser.matchToken(-1)
+				switch {
+				case first:
+					return flowNormal, false, parser.syntaxError("Mismatched input '%v', expected <statement>")
+				case defaultCase:
+					return flowNormal, false, parser.syntaxError("Mismatched input '%v', expected <statement> or '}'")
+				default:
+					return flowNormal, false, parser.syntaxError("Mismatched input '%v', expected <statement>, another 'case' or 'default' keyword or '}'")
+				}
+			}
+			return flowNormal, false, err
 		}
 	}
-	return nil
 }
 
-// arrayAccess
-// : LARRAY expression RARRAY
-// ;
-func (parser *parser) parseArrayAccess(parserInterface Parser) error {
-	if !parser.matchToken(tokens.LSBRACKET) {
-		return parser.syntaxError("lexicalError")
+// caseValue returns the value of a case terminal to find duplicate cases, literals are compared without quotes
+func caseValue(token tokens.IToken) string {
+	if literal, ok := token.(tokens.ILiteral); ok {
+		content := literal.GetContent()
+		return content[1 : len(content)-1]
+	}
+	return token.String()
+}
+
+// parseDeclaration parses the declaration of variables and adds them to the current scope
+//
+// declaration
+//   : CONST? declarationTargets (ASSIGN values)?
+//   ;
+func (parser *parser) parseDeclaration(parserInterface Parser) error {
+	constant := parser.lookAHead(tokens.CONST)
+	if constant {
+		if !parser.matchToken(tokens.CONST) {
+			return parser.syntaxError("lexicalError")
+		}
 	}
-	if err := parserInterface.parseExpression(parserInterface); err != nil {
+	targets, err := parser.parseDeclarationTargets(parserInterface, false, constant)
+	if err != nil {
 		return err
 	}
-	parser.lineBreakDelimiter = true
-	if !parser.matchToken(tokens.RSBRACKET) {
-		return parser.syntaxError("Mismatched input '%v', expected ']'")
+	return parser.parseDeclarationValues(parserInterface, targets, constant)
+}
+
+// parseDeclarationValues parses the optional values of a local declaration and adds the declared variables to the
+// current scope
+func (parser *parser) parseDeclarationValues(parserInterface Parser, targets []*target, constant bool) error {
+	if parser.lookAHead(tokens.ASSIGN) {
+		if !parser.matchToken(tokens.ASSIGN) {
+			return parser.syntaxError("lexicalError")
+		}
+		values, err := parser.parseValues(parserInterface)
+		if err != nil {
+			return err
+		}
+		if err := parser.destructure(targetTypes(targets), values, true); err != nil {
+			return err
+		}
 	}
+	parser.addTargets(targets, constant)
+	parser.declareReferences(targets)
 	return nil
 }
 
-// expression
-// : term ((PLUS | MINUS) term)*
-// ;
-func (parser *parser) parseExpression(parserInterface Parser) error {
-	if err := parserInterface.parseTerm(parserInterface); err != nil {
-		return err
+// parseAssignmentOrCall parses an assignment or a function call, the leading identifier is the current token
+//
+// assignmentOrCall
+//   : qualifiedIdentifier (arrayAccess | fieldAccess | callArguments)* callArguments
+//   | ID (arrayAccess | fieldAccess | callArguments)* (callArguments | assignmentOperator | destructuringAssignment)
+//   ;
+func (parser *parser) parseAssignmentOrCall(parserInterface Parser) error {
+	var symbol *utils.Symbol
+	name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	if name == blank {
+		return parser.parseDestructuringAssignment(parserInterface, []language.IBasicType{nil})
+	}
+	if imported, ok := parser.imports[name]; ok {
+		var err error
+		if symbol, err = parser.parseQualifiedIdentifier(imported); err != nil {
+			return err
+		}
+		// declarations of other modules can only be called
+		if !parser.lookAHead(tokens.LBRACKET) {
+			_ = parser.matchToken(-1)
+			return parser.syntaxError("Mismatched input '%v', expected '('")
+		}
+	} else {
+		parser.reference(name)
+		parser.capture(name)
+		symbol, _ = parser.table.Lookup(name)
+	}
+	indirect := parser.indirect(symbol)
+	targetType, called, err := parser.parseAccessOrCall(parserInterface, name, symbol)
+	if err != nil {
+		return err
+	}
+	// a call is a statement on its own
+	if called {
+		return nil
+	}
+	if parser.lookAHeadAssignmentOperator() || parser.lookAHead(tokens.COMMA) {
+		if err := parser.constantError(name, symbol); err != nil {
+			return err
+		}
+	}
+	if parser.lookAHead(tokens.COMMA) {
+		return parser.parseDestructuringAssignment(parserInterface, []language.IBasicType{targetType})
+	}
+	if err := parser.parseAssignmentOperator(parserInterface, targetType, "Mismatched input '%v', expected '(', '[', '.', ',', '=' or <assignment_operator>"); err != nil {
+		return err
+	}
+	return parser.storeReference(name, symbol, indirect)
+}
+
+// lookAHeadAssignmentOperator checks if the next token assigns a value to the target in front of it
+func (parser *parser) lookAHeadAssignmentOperator() bool {
+	_, compound := compoundOperators[parser.nextToken.GetTag()]
+	return compound || parser.lookAHead(tokens.ASSIGN) || parser.lookAHead(tokens.INC) || parser.lookAHead(tokens.DEC)
+}
+
+// parseAssignmentOperator parses the assignment of a value to a variable, array element or field. Compound assignments
+// combine the target and the value with the operator, increments and decrements add or subtract one from a number.
+// The error message is reported if the next token is no assignment operator.
+//
+// assignmentOperator
+// : (ASSIGN | ADDASSIGN | SUBASSIGN | MULTASSIGN | DIVASSIGN | MODASSIGN | ANDASSIGN | ORASSIGN | XORASSIGN | SHLASSIGN | SHRASSIGN) booleanExpression
+// | INC
+// | DEC
+// ;
+func (parser *parser) parseAssignmentOperator(parserInterface Parser, targetType language.IBasicType, errorMessage string) error {
+	operator, compound := compoundOperators[parser.nextToken.GetTag()]
+	switch {
+	case parser.lookAHead(tokens.INC), parser.lookAHead(tokens.DEC):
+		if !parser.matchToken(parser.nextToken.GetTag()) {
+			return parser.syntaxError("lexicalError")
+		}
+		if !numeric(targetType) {
+			return parser.operatorError(parser.currentToken, targetType)
+		}
+		return nil
+	case parser.lookAHead(tokens.ASSIGN), compound:
+		if !parser.matchToken(parser.nextToken.GetTag()) {
+			return parser.syntaxError("lexicalError")
+		}
+	default:
+		_ = parser.matchToken(-1)
+		return parser.syntaxError(errorMessage)
+	}
+	operatorToken := parser.currentToken
+	valueType, err := parserInterface.parseBooleanExpression(parserInterface)
+	if err != nil {
+		return err
+	}
+	if compound {
+		resultType, ok := binaryType(operator, targetType, valueType)
+		if !ok {
+			return parser.operatorError(operatorToken, targetType, valueType)
+		}
+		valueType = resultType
+	}
+	if err := parser.storeError(targetType, valueType); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseForStatement parses C-style and range loops. The loop variables are only visible inside the loop, therefore a
+// new scope is opened before the loop header.
+//
+// forStatement
+//   : FOR (declaration | inferredDeclaration | assignmentOrCall)? DELIMITER booleanExpression? DELIMITER assignmentOrCall? scopeStatement
+//   | FOR ID (COMMA ID)? IN booleanExpression scopeStatement
+//   ;
+func (parser *parser) parseForStatement(parserInterface Parser) error {
+	if !parser.matchToken(tokens.FOR) {
+		return parser.syntaxError("lexicalError")
+	}
+	parser.table.NewScope("for")
+	parser.enterJumpTarget(true)
+	switch {
+	case parser.lookAHeadInferredDeclaration():
+		if err := parser.parseInferredDeclaration(parserInterface); err != nil {
+			return err
+		}
+	case !parser.postfixTypes() && (parser.lookAHead(tokens.CONST) || parser.lookAHeadType()):
+		if err := parserInterface.parseDeclaration(parserInterface); err != nil {
+			return err
+		}
+	case parser.lookAHead(tokens.ID):
+		if !parser.matchToken(tokens.ID) {
+			return parser.syntaxError("lexicalError")
+		}
+		// FOR ID (COMMA ID)? IN booleanExpression scopeStatement
+		if parser.lookAHead(tokens.COMMA) || parser.lookAHead(tokens.IN) {
+			if err := parser.parseForRange(parserInterface); err != nil {
+				return err
+			}
+			parser.leaveJumpTarget()
+			parser.table.LeaveScope()
+			parser.flow = flowNormal
+			return nil
+		}
+		if err := parser.parseDeclarationOrAssignment(parserInterface); err != nil {
+			return err
+		}
+	}
+	if !parser.matchToken(tokens.DELIMITER) {
+		return parser.syntaxError("Mismatched input '%v', expected ';'")
+	}
+	infinite := parser.lookAHead(tokens.DELIMITER)
+	if !infinite {
+		conditionType, err := parserInterface.parseBooleanExpression(parserInterface)
+		if err != nil {
+			return err
+		}
+		if err := parser.conditionError(conditionType); err != nil {
+			return err
+		}
+	}
+	if !parser.matchToken(tokens.DELIMITER) {
+		return parser.syntaxError("Mismatched input '%v', expected ';'")
+	}
+	if parser.lookAHead(tokens.ID) {
+		if !parser.matchToken(tokens.ID) {
+			return parser.syntaxError("lexicalError")
+		}
+		if err := parserInterface.parseAssignmentOrCall(parserInterface); err != nil {
+			return err
+		}
+	}
+	if err := parserInterface.parseScope(parserInterface); err != nil {
+		return err
+	}
+	parser.table.LeaveScope()
+	// a loop without condition is only left by break, without break the statements after the loop are never executed
+	if target := parser.leaveJumpTarget(); infinite && !target.broken {
+		parser.flow = flowReturn
+	} else {
+		parser.flow = flowNormal
+	}
+	return nil
+}
+
+// parseForRange parses the remaining part of a range loop, the first loop variable is the current token. With two
+// variables the first one is the index and the second one the element, a single variable is the element.
+func (parser *parser) parseForRange(parserInterface Parser) error {
+	names := []string{parser.currentToken.GetToken().(tokens.IWord).GetLexeme()}
+	if parser.lookAHead(tokens.COMMA) {
+		if !parser.matchToken(tokens.COMMA) {
+			return parser.syntaxError("lexicalError")
+		}
+		if !parser.matchToken(tokens.ID) {
+			return parser.syntaxError("Mismatched input '%v', expected <identifier>")
+		}
+		names = append(names, parser.currentToken.GetToken().(tokens.IWord).GetLexeme())
+	}
+	if !parser.matchToken(tokens.IN) {
+		return parser.syntaxError("Mismatched input '%v', expected 'in'")
+	}
+	rangeType, err := parserInterface.parseBooleanExpression(parserInterface)
+	if err != nil {
+		return err
+	}
+	switch rangeType.(type) {
+	case nil, *language.ArrayType, *language.SliceType, *language.StringType:
+	default:
+		return parser.typeError("Mismatched type '%v' in range loop, expected array, slice or str", rangeType)
+	}
+	if len(names) == 2 {
+		parser.table.Add(utils.NewSymbol(names[0], language.IntType, false, false))
+	}
+	parser.table.Add(utils.NewSymbol(names[len(names)-1], elementType(rangeType), false, false))
+	return parserInterface.parseScope(parserInterface)
+}
+
+// conditionalScope
+//   : booleanExpression scopeStatement
+//   ;
+func (parser *parser) parseConditionalScope(parserInterface Parser) error {
+	conditionType, err := parserInterface.parseBooleanExpression(parserInterface)
+	if err != nil {
+		return err
+	}
+	if err := parser.conditionError(conditionType); err != nil {
+		return err
+	}
+	return parserInterface.parseScope(parserInterface)
+}
+
+// conditionError returns a vega error on conditions of if statements and loops which are not bool
+func (parser *parser) conditionError(conditionType language.IBasicType) error {
+	if boolean(conditionType) {
+		return nil
+	}
+	return parser.typeError("Mismatched type '%v' in condition, expected 'bool'", conditionType)
+}
+
+// parseBooleanExpression parses disjunctions. The right operand of or is only evaluated when the left operand is
+// false, so it binds weaker than and.
+//
+// booleanExpression
+//   :   andExpression ((OR | BOOLOR) andExpression)*
+//   ;
+func (parser *parser) parseBooleanExpression(parserInterface Parser) (language.IBasicType, error) {
+	exprType, err := parserInterface.parseAndExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	var loopControl = false
+	for {
+		switch {
+		case parser.lookAHead(tokens.OR):
+			if !parser.matchToken(tokens.OR) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.BOOLOR):
+			if !parser.matchToken(tokens.BOOLOR) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		default:
+			loopControl = true
+		}
+		if loopControl {
+			break
+		}
+		operator := parser.currentToken
+		operandType, err := parserInterface.parseAndExpression(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		if !boolean(exprType) || !boolean(operandType) {
+			return nil, parser.operatorError(operator, exprType, operandType)
+		}
+		exprType = language.BoolType
+	}
+	return exprType, nil
+}
+
+// parseAndExpression parses conjunctions, the right operand of and is only evaluated when the left operand is true
+//
+// andExpression
+//   :   notExpression ((AND | BOOLAND) notExpression)*
+//   ;
+func (parser *parser) parseAndExpression(parserInterface Parser) (language.IBasicType, error) {
+	exprType, err := parserInterface.parseNotExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	var loopControl = false
+	for {
+		switch {
+		case parser.lookAHead(tokens.AND):
+			if !parser.matchToken(tokens.AND) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.BOOLAND):
+			if !parser.matchToken(tokens.BOOLAND) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		default:
+			loopControl = true
+		}
+		if loopControl {
+			break
+		}
+		operator := parser.currentToken
+		operandType, err := parserInterface.parseNotExpression(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		if !boolean(exprType) || !boolean(operandType) {
+			return nil, parser.operatorError(operator, exprType, operandType)
+		}
+		exprType = language.BoolType
+	}
+	return exprType, nil
+}
+
+// parseNotExpression parses negations, not binds weaker than comparisons: not a < b negates the comparison
+//
+// notExpression
+//   :   (NOT | EXCLAMATION) notExpression
+//   |   comparisonExpression
+//   ;
+func (parser *parser) parseNotExpression(parserInterface Parser) (language.IBasicType, error) {
+	switch {
+	case parser.lookAHead(tokens.NOT):
+		if !parser.matchToken(tokens.NOT) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	case parser.lookAHead(tokens.EXCLAMATION):
+		if !parser.matchToken(tokens.EXCLAMATION) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	default:
+		return parserInterface.parseComparisonExpression(parserInterface)
+	}
+	operator := parser.currentToken
+	operandType, err := parserInterface.parseNotExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if !boolean(operandType) {
+		return nil, parser.operatorError(operator, operandType)
+	}
+	return language.BoolType, nil
+}
+
+// parseComparisonExpression parses the comparison of two values, comparisons cannot be chained: a < b < c has to be
+// written as a < b and b < c
+//
+// comparisonExpression
+// :   expression (comparisonOperator expression)?
+// ;
+func (parser *parser) parseComparisonExpression(parserInterface Parser) (language.IBasicType, error) {
+	exprType, err := parserInterface.parseExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if !comparisonOperator(parser.nextToken.GetTag()) {
+		return exprType, nil
+	}
+	if !parser.matchToken(parser.nextToken.GetTag()) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	operator := parser.currentToken
+	operandType, err := parserInterface.parseExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if !comparableTypes(exprType, operandType) {
+		return nil, parser.operatorError(operator, exprType, operandType)
+	}
+	if orderingOperator(operator.GetTag()) && (!orderable(exprType) || !orderable(operandType)) {
+		return nil, parser.operatorError(operator, exprType, operandType)
+	}
+	if comparisonOperator(parser.nextToken.GetTag()) {
+		_ = parser.matchToken(-1)
+		return nil, parser.syntaxError("Unexpected '%v', comparisons cannot be chained")
+	}
+	return language.BoolType, nil
+}
+
+// parseArrayAccess parses the access of an element or a slice expression and returns the type of the result. Slicing
+// an array or a slice results in a slice sharing the elements, slicing a str results in a str. Constant indices are
+// checked against the size of arrays, all other indices are checked when the program is executed.
+//
+// arrayAccess
+// : LARRAY expression RARRAY
+// | LARRAY expression? COLON expression? RARRAY
+// ;
+func (parser *parser) parseArrayAccess(parserInterface Parser, arrayType language.IBasicType) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.LSBRACKET) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	size := -1
+	if array, ok := arrayType.(*language.ArrayType); ok {
+		size = array.GetSize()
+	}
+	var lowToken, highToken *lexicalToken
+	low, high := 0, -1
+	if !parser.lookAHead(tokens.COLON) {
+		var err error
+		if lowToken, low, err = parser.parseIndex(parserInterface); err != nil {
+			return nil, err
+		}
+		// LARRAY expression RARRAY
+		if !parser.lookAHead(tokens.COLON) {
+			if !parser.matchToken(tokens.RSBRACKET) {
+				return nil, parser.syntaxError("Mismatched input '%v', expected ':' or ']'")
+			}
+			switch arrayType.(type) {
+			case nil, *language.ArrayType, *language.SliceType, *language.StringType:
+			default:
+				return nil, parser.typeError("Mismatched type '%v' in index expression, expected array, slice or str", arrayType)
+			}
+			if size >= 0 && low >= size {
+				return nil, parser.indexError(lowToken, low, arrayType)
+			}
+			return elementType(arrayType), nil
+		}
+	}
+	if !parser.matchToken(tokens.COLON) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	if !parser.lookAHead(tokens.RSBRACKET) {
+		var err error
+		if highToken, high, err = parser.parseIndex(parserInterface); err != nil {
+			return nil, err
+		}
+	}
+	if !parser.matchToken(tokens.RSBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected ']'")
+	}
+	switch {
+	case size >= 0 && low > size:
+		return nil, parser.indexError(lowToken, low, arrayType)
+	case size >= 0 && high > size:
+		return nil, parser.indexError(highToken, high, arrayType)
+	case low > high && high >= 0:
+		errMsg := fmt.Sprintf("Invalid slice indices %v > %v", low, high)
+		return nil, parser.newParserTypeError(indexOutOfBounds, highToken, errMsg, parser.lexer.getLineFeed())
+	}
+	switch arrayType.(type) {
+	case nil:
+		return nil, nil
+	case *language.ArrayType, *language.SliceType:
+		return language.NewSlice(elementType(arrayType)), nil
+	case *language.StringType:
+		return language.NewString(0), nil
+	default:
+		return nil, parser.typeError("Mismatched type '%v' in slice expression, expected array, slice or str", arrayType)
+	}
+}
+
+// parseIndex parses an index or a bound of a slice expression, which has to be an integer. The value of an index given
+// as number is returned, otherwise -1.
+func (parser *parser) parseIndex(parserInterface Parser) (*lexicalToken, int, error) {
+	first := parser.nextToken
+	indexType, err := parserInterface.parseExpression(parserInterface)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !assignable(language.IntType, indexType) {
+		return nil, 0, parser.typeError("Mismatched type '%v' in index, expected 'int'", indexType)
+	}
+	if first.GetTag() == tokens.NUM && parser.currentToken == first {
+		return first, first.GetToken().(tokens.INum).GetValue(), nil
+	}
+	return first, -1, nil
+}
+
+// indexError returns a vega error on constant indices exceeding the size of an array
+func (parser *parser) indexError(token *lexicalToken, index int, arrayType language.IBasicType) error {
+	errMsg := fmt.Sprintf("Index %v out of bounds for '%v'", index, typeName(arrayType))
+	return parser.newParserTypeError(indexOutOfBounds, token, errMsg, parser.lexer.getLineFeed())
+}
+
+// parseFieldAccess parses the access of a struct field and returns the type of the field
+//
+// fieldAccess
+// : DOT ID
+// ;
+func (parser *parser) parseFieldAccess(parserInterface Parser, structType language.IBasicType) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.DOT) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	// fields of a struct referred to by a pointer are accessed through the pointer
+	if pointer, ok := structType.(*language.PointerType); ok && pointer != nilPointer {
+		structType = pointer.GetElementType()
+	}
+	switch t := structType.(type) {
+	case nil:
+		return nil, nil
+	case language.IStructType:
+		field, ok := t.GetField(parser.currentToken.GetToken().(tokens.IWord).GetLexeme())
+		if !ok {
+			return nil, parser.typeError("Unknown field '%v' in type '%v'", parser.currentToken.GetToken(), t)
+		}
+		return field.Type, nil
+	case *language.ResultType:
+		return parser.resultField(t)
+	default:
+		return nil, parser.typeError("Mismatched type '%v' in field access, expected struct", t)
+	}
+}
+
+// expression
+// : term ((PLUS | MINUS | LOGOR | XOR) term)*
+// ;
+func (parser *parser) parseExpression(parserInterface Parser) (language.IBasicType, error) {
+	exprType, err := parserInterface.parseTerm(parserInterface)
+	if err != nil {
+		return nil, err
 	}
 	var loopControl = false
 	for {
 		switch {
 		case parser.lookAHead(tokens.ADD):
 			if !parser.matchToken(tokens.ADD) {
-				return parser.syntaxError("lexicalError")
+				return nil, parser.syntaxError("lexicalError")
 			}
 		case parser.lookAHead(tokens.SUB):
 			if !parser.matchToken(tokens.SUB) {
-				return parser.syntaxError("lexicalError")
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.LOGOR):
+			if !parser.matchToken(tokens.LOGOR) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.XOR):
+			if !parser.matchToken(tokens.XOR) {
+				return nil, parser.syntaxError("lexicalError")
 			}
 		default:
 			loopControl = true
@@ -723,30 +1797,54 @@ func (parser *parser) parseExpression(parserInterface Parser) error {
 		if loopControl {
 			break
 		}
-		if err := parserInterface.parseTerm(parserInterface); err != nil {
-			return err
+		operator := parser.currentToken
+		termType, err := parserInterface.parseTerm(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		resultType, ok := binaryType(operator.GetTag(), exprType, termType)
+		if !ok {
+			return nil, parser.operatorError(operator, exprType, termType)
 		}
+		exprType = resultType
 	}
-	return nil
+	return exprType, nil
 }
 
 // term
-// : factor ((MULT | DIV) factor)*
+// : factor ((MULT | DIV | MOD | LOGAND | SHL | SHR) factor)*
 // ;
-func (parser *parser) parseTerm(parserInterface Parser) error {
-	if err := parserInterface.parseFactor(parserInterface); err != nil {
-		return err
+func (parser *parser) parseTerm(parserInterface Parser) (language.IBasicType, error) {
+	termType, err := parserInterface.parseFactor(parserInterface)
+	if err != nil {
+		return nil, err
 	}
 	var loopControl = false
-	for loopControl {
+	for {
 		switch {
 		case parser.lookAHead(tokens.MULT):
 			if !parser.matchToken(tokens.MULT) {
-				return parser.syntaxError("lexicalError")
+				return nil, parser.syntaxError("lexicalError")
 			}
 		case parser.lookAHead(tokens.DIV):
 			if !parser.matchToken(tokens.DIV) {
-				return parser.syntaxError("lexicalError")
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.MOD):
+			if !parser.matchToken(tokens.MOD) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.LOGAND):
+			if !parser.matchToken(tokens.LOGAND) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.SHL):
+			if !parser.matchToken(tokens.SHL) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+		case parser.lookAHead(tokens.SHR):
+			if !parser.matchToken(tokens.SHR) {
+				return nil, parser.syntaxError("lexicalError")
 			}
 		default:
 			loopControl = true
@@ -754,146 +1852,556 @@ func (parser *parser) parseTerm(parserInterface Parser) error {
 		if loopControl {
 			break
 		}
-		if err := parserInterface.parseFactor(parserInterface); err != nil {
-			return err
+		operator := parser.currentToken
+		factorType, err := parserInterface.parseFactor(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		resultType, ok := binaryType(operator.GetTag(), termType, factorType)
+		if !ok {
+			return nil, parser.operatorError(operator, termType, factorType)
 		}
+		termType = resultType
 	}
-	return nil
+	return termType, nil
 }
 
 // factor
-// : (MINUS | NOT)? unary
+// : (MINUS | TILDE)? unary
+// | dereference
+// | address
+// | try
 // ;
-func (parser *parser) parseFactor(parserInterface Parser) error {
+func (parser *parser) parseFactor(parserInterface Parser) (language.IBasicType, error) {
 	switch {
-	case parser.lookAHead(tokens.EXCLAMATION):
-		if !parser.matchToken(tokens.EXCLAMATION) {
-			return parser.syntaxError("lexicalError")
-		}
-	case parser.lookAHead(tokens.NOT):
-		if !parser.matchToken(tokens.NOT) {
-			return parser.syntaxError("lexicalError")
+	case parser.lookAHead(tokens.TRY):
+		return parser.parseTry(parserInterface)
+	case parser.lookAHead(tokens.MULT):
+		return parser.parseDereference(parserInterface)
+	case parser.lookAHead(tokens.LOGAND):
+		return parser.parseAddress(parserInterface)
+	case parser.lookAHead(tokens.SUB):
+		if !parser.matchToken(tokens.SUB) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	case parser.lookAHead(tokens.TILDE):
+		if !parser.matchToken(tokens.TILDE) {
+			return nil, parser.syntaxError("lexicalError")
 		}
+	default:
+		return parserInterface.parseUnary(parserInterface)
+	}
+	operator := parser.currentToken
+	unaryType, err := parserInterface.parseUnary(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if (operator.GetTag() == tokens.SUB && !numeric(unaryType)) || (operator.GetTag() == tokens.TILDE && !integer(unaryType)) {
+		return nil, parser.operatorError(operator, unaryType)
 	}
-	return parserInterface.parseUnary(parserInterface)
+	return unaryType, nil
 }
 
 // unary
 // : (BASIC | TRUE | FALSE | LITERAL)
-// | ID arrayAccess?
-// | ID LBRACKET ( booleanExpression (COMMA booleanExpression)* )? RBRACKET   // func call
+// | (ID | qualifiedIdentifier) (arrayAccess | fieldAccess | callArguments)*
+// | functionLiteral
+// | conversion
+// | variant
+// | structLiteral
 // | LBRACKET booleanExpression RBRACKET
-// | LARRAY (expression (COMMA expression)* )? RARRAY           // set array value
+// | LARRAY (expression (COMMA expression)* )? RARRAY           // set array value, the elements have a common type
+// | stringInterpolation
 // ;
-func (parser *parser) parseUnary(parserInterface Parser) error {
+func (parser *parser) parseUnary(parserInterface Parser) (language.IBasicType, error) {
+	var unaryType language.IBasicType
 	switch {
-	// ID (arrayAccess?) | ID LBRACKET ( expression (COMMA expression)* )? RBRACKET
+	// conversion
+	case parser.lookAHead(tokens.BASIC):
+		return parserInterface.parseConversion(parserInterface)
+	// variant
+	case parser.lookAHeadEnum():
+		return parser.parseVariant()
+	// structLiteral
+	case parser.lookAHeadType() && parser.lookAHead(tokens.ID):
+		return parserInterface.parseStructLiteral(parserInterface)
+	// (ID | qualifiedIdentifier) (arrayAccess | fieldAccess | callArguments)*
 	case parser.lookAHead(tokens.ID):
 		if !parser.matchToken(tokens.ID) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
-		// arrayAccess?
-		if parser.lookAHead(tokens.LSBRACKET) {
-			if err := parserInterface.parseArrayAccess(parserInterface); err != nil {
-				return err
-			}
-			// LBRACKET ( booleanExpression (COMMA booleanExpression)* )? RBRACKET
-		} else if parser.lookAHead(tokens.LBRACKET) {
-			if !parser.matchToken(tokens.LBRACKET) {
-				return parser.syntaxError("lexicalError")
-			}
-			if !parser.lookAHead(tokens.RBRACKET) {
-				if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-					return err
-				}
-				for parser.lookAHead(tokens.COMMA) {
-					if !parser.matchToken(tokens.COMMA) {
-						return parser.syntaxError("lexicalError")
-					}
-					if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-						return err
-					}
-				}
+		name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+		if name == blank {
+			return nil, parser.syntaxError("Cannot use '%v' as value")
+		}
+		var symbol *utils.Symbol
+		if imported, ok := parser.imports[name]; ok {
+			var err error
+			if symbol, err = parser.parseQualifiedIdentifier(imported); err != nil {
+				return nil, err
 			}
-			parser.lineBreakDelimiter = true
-			if !parser.matchToken(tokens.RBRACKET) {
-				return parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
+			// values of imported modules are only known at compile time when they are constant
+			if parser.declaration != nil && (symbol.Callable || !symbol.Const) {
+				parser.declaration.dynamic = true
 			}
+		} else {
+			parser.reference(name)
+			parser.capture(name)
+			symbol, _ = parser.table.Lookup(name)
 		}
+		valueType, called, err := parser.parseAccessOrCall(parserInterface, name, symbol)
+		if err != nil {
+			return nil, err
+		}
+		if !called && parser.frameRefs[symbol] {
+			parser.frameRef = parser.currentToken
+		}
+		unaryType = valueType
+	// functionLiteral
+	case parser.lookAHead(tokens.FUNC):
+		return parserInterface.parseFunctionLiteral(parserInterface)
 	// LBRACKET booleanExpression RBRACKET
 	case parser.lookAHead(tokens.LBRACKET):
 		if !parser.matchToken(tokens.LBRACKET) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
-		if err := parserInterface.parseBooleanExpression(parserInterface); err != nil {
-			return err
+		exprType, err := parserInterface.parseBooleanExpression(parserInterface)
+		if err != nil {
+			return nil, err
 		}
-		parser.lineBreakDelimiter = true
+		reference := parser.referencesFrame()
 		if !parser.matchToken(tokens.RBRACKET) {
-			return parser.syntaxError("Mismatched input '%v', expected ')'")
+			return nil, parser.syntaxError("Mismatched input '%v', expected ')'")
+		}
+		if reference {
+			parser.frameRef = parser.currentToken
 		}
+		unaryType = exprType
 	// LARRAY (expression (COMMA expression)* )? RARRAY
 	case parser.lookAHead(tokens.LSBRACKET):
 		if !parser.matchToken(tokens.LSBRACKET) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
-		if err := parserInterface.parseExpression(parserInterface); err != nil {
-			return err
+		// the type of an empty array literal is only known from the variable it is stored in
+		if parser.lookAHead(tokens.RSBRACKET) {
+			if !parser.matchToken(tokens.RSBRACKET) {
+				return nil, parser.syntaxError("lexicalError")
+			}
+			return nil, nil
+		}
+		exprType, err := parserInterface.parseExpression(parserInterface)
+		if err != nil {
+			return nil, err
 		}
+		size := 1
 		for parser.lookAHead(tokens.COMMA) {
 			if !parser.matchToken(tokens.COMMA) {
-				return parser.syntaxError("lexicalError")
+				return nil, parser.syntaxError("lexicalError")
 			}
-			if err := parserInterface.parseExpression(parserInterface); err != nil {
-				return err
+			elemType, err := parserInterface.parseExpression(parserInterface)
+			if err != nil {
+				return nil, err
 			}
+			// integers are promoted when the literal contains floating point numbers
+			switch {
+			case assignable(exprType, elemType):
+			case assignable(elemType, exprType):
+				exprType = elemType
+			default:
+				return nil, parser.typeError("Mismatched type '%v' in array literal, expected '%v'", elemType, exprType)
+			}
+			size++
 		}
-		parser.lineBreakDelimiter = true
 		if !parser.matchToken(tokens.RSBRACKET) {
-			return parser.syntaxError("Mismatched input '%v', expected ',' or ']'")
+			return nil, parser.syntaxError("Mismatched input '%v', expected ',' or ']'")
 		}
+		unaryType = arrayOf(exprType, size)
+	// stringInterpolation
+	case parser.lookAHead(tokens.INTERPSTART):
+		return parserInterface.parseStringInterpolation(parserInterface)
 	//
 	default:
-		if err := parserInterface.parseTerminal(); err != nil {
-			return parser.syntaxError("Mismatched input '%v', expected <unary>")
+		terminalType, err := parserInterface.parseTerminal()
+		if err != nil {
+			return nil, parser.syntaxError("Mismatched input '%v', expected <unary>")
+		}
+		unaryType = terminalType
+	}
+	return unaryType, nil
+}
+
+// parseAccessOrCall parses the element and field accesses and the calls following an identifier. It returns the
+// resulting type and reports if the last part was a call. Declared functions are function values, functions of the
+// prelude without fixed signature have to be called directly.
+func (parser *parser) parseAccessOrCall(parserInterface Parser, name string, symbol *utils.Symbol) (language.IBasicType, bool, error) {
+	var valueType language.IBasicType
+	called := false
+	if symbol != nil {
+		valueType = symbol.SymbolType
+		if symbol.Callable {
+			valueType = language.NewFunction(symbol.Params, symbol.SymbolType)
+		}
+		// generic functions are only instantiated by calls
+		if len(symbol.TypeParams) > 0 && !parser.lookAHead(tokens.LBRACKET) {
+			return nil, false, parser.typeError("Cannot use generic function '%v' without calling it", name)
+		}
+		if symbol.Builtin && prelude[name].check != nil {
+			if !parser.lookAHead(tokens.LBRACKET) {
+				_ = parser.matchToken(-1)
+				return nil, false, parser.syntaxError("Mismatched input '%v', expected '('")
+			}
+			argTypes, argNames, err := parser.parseCallArguments(parserInterface)
+			if err != nil {
+				return nil, false, err
+			}
+			if err := parser.unnamedArguments(name, argNames); err != nil {
+				return nil, false, err
+			}
+			if valueType, err = parser.builtinCall(name, argTypes); err != nil {
+				return nil, false, err
+			}
+			called = true
+		}
+	}
+	for parser.lookAHead(tokens.LSBRACKET) || parser.lookAHead(tokens.DOT) || parser.lookAHead(tokens.LBRACKET) {
+		var err error
+		switch {
+		case parser.lookAHead(tokens.DOT):
+			valueType, err = parserInterface.parseFieldAccess(parserInterface, valueType)
+			called = false
+		case parser.lookAHead(tokens.LSBRACKET):
+			valueType, err = parserInterface.parseArrayAccess(parserInterface, valueType)
+			called = false
+		case symbol != nil && symbol.Callable && !symbol.Builtin:
+			// direct calls of declared functions can use named arguments and omit parameters with default values
+			var argTypes []language.IBasicType
+			var argNames []string
+			if argTypes, argNames, err = parser.parseCallArguments(parserInterface); err == nil {
+				valueType, err = parser.bindArguments(name, symbol, argTypes, argNames)
+			}
+			called, symbol = true, nil
+		default:
+			valueType, err = parser.parseCall(parserInterface, name, valueType)
+			called = true
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return valueType, called, nil
+}
+
+// parseCall parses the call of a function value and returns the result of the function. The arguments have to match
+// the parameter types of the function, the result of calling a value which is not a function is unknown.
+func (parser *parser) parseCall(parserInterface Parser, name string, calleeType language.IBasicType) (language.IBasicType, error) {
+	argTypes, argNames, err := parser.parseCallArguments(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.unnamedArguments(name, argNames); err != nil {
+		return nil, err
+	}
+	function, ok := calleeType.(*language.FunctionType)
+	if !ok {
+		return nil, nil
+	}
+	// the results of functions are only known when the program is executed
+	if parser.declaration != nil {
+		parser.declaration.dynamic = true
+	}
+	params := function.GetParams()
+	if len(argTypes) != len(params) {
+		return nil, parser.argumentCountError(name, strconv.Itoa(len(params)))
+	}
+	for i, param := range params {
+		if !assignable(param, argTypes[i]) {
+			return nil, parser.typeError("Mismatched type '%v' for argument %v of '%v', expected '%v'", argTypes[i], i+1, name, param)
+		}
+	}
+	return function.GetResult(), nil
+}
+
+// bindArguments assigns the arguments of a direct call to the parameters of a declared function and returns the result
+// type. Positional arguments are assigned in order and have to precede the named arguments, parameters with default
+// values can be omitted. The values are checked like assignments to the parameters, the parameters of generic functions
+// are instantiated with the type arguments inferred from the values.
+func (parser *parser) bindArguments(name string, function *utils.Symbol, argTypes []language.IBasicType, argNames []string) (language.IBasicType, error) {
+	args := make([]language.IBasicType, len(function.Params))
+	bound := make([]bool, len(function.Params))
+	named := false
+	for i, argName := range argNames {
+		index := i
+		switch {
+		case argName != "":
+			named, index = true, -1
+			for j, paramName := range function.ParamNames {
+				if paramName == argName {
+					index = j
+				}
+			}
+			if index < 0 {
+				return nil, parser.typeError("Unknown argument '%v' in call of '%v'", argName, name)
+			}
+		case named:
+			return nil, parser.typeError("Positional argument after named arguments in call of '%v'", name)
+		case index >= len(function.Params):
+			count := strconv.Itoa(len(function.Params))
+			if function.Defaults > 0 {
+				count = "at most " + count
+			}
+			return nil, parser.argumentCountError(name, count)
+		}
+		if bound[index] {
+			return nil, parser.typeError("Duplicate argument '%v' in call of '%v'", function.ParamNames[index], name)
+		}
+		bound[index] = true
+		args[index] = argTypes[i]
+	}
+	for i, param := range function.ParamNames[:len(function.ParamNames)-function.Defaults] {
+		if !bound[i] {
+			return nil, parser.typeError("Missing argument '%v' in call of '%v'", param, name)
+		}
+	}
+	params, result := function.Params, function.SymbolType
+	if len(function.TypeParams) > 0 {
+		var err error
+		if params, result, err = parser.instantiate(name, function, args); err != nil {
+			return nil, err
+		}
+	}
+	for i, param := range params {
+		if !bound[i] {
+			continue
+		}
+		if !narrowing(param, args[i]) && !assignable(param, args[i]) {
+			return nil, parser.typeError("Mismatched type '%v' for argument %v of '%v', expected '%v'", args[i], i+1, name, param)
+		}
+		if err := parser.storeError(param, args[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// unnamedArguments returns a vega error on named arguments in calls of functions whose parameter names are unknown,
+// e.g. function values and functions of the prelude
+func (parser *parser) unnamedArguments(name string, argNames []string) error {
+	for _, argName := range argNames {
+		if argName != "" {
+			return parser.typeError("Unknown argument '%v' in call of '%v'", argName, name)
 		}
 	}
 	return nil
 }
 
+// parseCallArguments parses the arguments of a function call and returns their types and names, the name of a
+// positional argument is empty
+//
+// callArguments
+// : LBRACKET ( argument (COMMA argument)* )? RBRACKET
+// ;
+//
+// argument
+// : (ID COLON)? booleanExpression
+// ;
+func (parser *parser) parseCallArguments(parserInterface Parser) ([]language.IBasicType, []string, error) {
+	if !parser.matchToken(tokens.LBRACKET) {
+		return nil, nil, parser.syntaxError("lexicalError")
+	}
+	var argTypes []language.IBasicType
+	var argNames []string
+	if !parser.lookAHead(tokens.RBRACKET) {
+		for {
+			argName := ""
+			if parser.lookAHead(tokens.ID) && parser.peekAHead(tokens.COLON) {
+				if !parser.matchToken(tokens.ID) {
+					return nil, nil, parser.syntaxError("lexicalError")
+				}
+				argName = parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+				if !parser.matchToken(tokens.COLON) {
+					return nil, nil, parser.syntaxError("lexicalError")
+				}
+			}
+			argType, err := parserInterface.parseBooleanExpression(parserInterface)
+			if err != nil {
+				return nil, nil, err
+			}
+			argTypes = append(argTypes, argType)
+			argNames = append(argNames, argName)
+			if !parser.lookAHead(tokens.COMMA) {
+				break
+			}
+			if !parser.matchToken(tokens.COMMA) {
+				return nil, nil, parser.syntaxError("lexicalError")
+			}
+		}
+	}
+	if !parser.matchToken(tokens.RBRACKET) {
+		return nil, nil, parser.syntaxError("Mismatched input '%v', expected ',' or ')'")
+	}
+	return argTypes, argNames, nil
+}
+
+// parseConversion parses the explicit conversion of a value into a basic type. Numbers and chars can be converted
+// into each other, floating point numbers are truncated when they are converted into integers.
+//
+// conversion
+// : (INT_TYPE | FLOAT_TYPE | CHAR_TYPE | BOOL_TYPE) LBRACKET booleanExpression RBRACKET
+// ;
+func (parser *parser) parseConversion(parserInterface Parser) (language.IBasicType, error) {
+	targetType, err := parserInterface.parseTerminalVariableType()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.matchToken(tokens.LBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '('")
+	}
+	valueType, err := parserInterface.parseBooleanExpression(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	if !parser.matchToken(tokens.RBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected ')'")
+	}
+	if !convertible(targetType, valueType) {
+		return nil, parser.typeError("Cannot convert '%v' to '%v'", valueType, targetType)
+	}
+	return targetType, nil
+}
+
+// parseStructLiteral parses the creation of a struct value. Fields are initialized by name, fields which are not
+// mentioned keep their zero value.
+//
+// structLiteral
+//   : ID LCURLY (ID COLON booleanExpression (COMMA ID COLON booleanExpression)* COMMA?)? RCURLY
+//   ;
+func (parser *parser) parseStructLiteral(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.ID) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	// other named types like type parameters cannot be created by a literal and are no values
+	namedType := parser.types[parser.currentToken.GetToken().(tokens.IWord).GetLexeme()]
+	structType, ok := namedType.(*language.StructType)
+	if !ok {
+		return nil, parser.typeError("Cannot use type '%v' as value", namedType)
+	}
+	if !parser.matchToken(tokens.LCBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '{'")
+	}
+	initialized := map[string]bool{}
+	for parser.lookAHead(tokens.ID) {
+		if !parser.matchToken(tokens.ID) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+		field, ok := structType.GetField(name)
+		if !ok {
+			return nil, parser.typeError("Unknown field '%v' in type '%v'", name, structType)
+		}
+		if initialized[name] {
+			return nil, parser.syntaxError("Duplicate field '%v' in struct literal")
+		}
+		initialized[name] = true
+		if !parser.matchToken(tokens.COLON) {
+			return nil, parser.syntaxError("Mismatched input '%v', expected ':'")
+		}
+		valueType, err := parserInterface.parseBooleanExpression(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		if !assignable(field.Type, valueType) {
+			return nil, parser.typeError("Mismatched type '%v' for field '%v', expected '%v'", valueType, name, field.Type)
+		}
+		if !parser.lookAHead(tokens.COMMA) {
+			break
+		}
+		if !parser.matchToken(tokens.COMMA) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	}
+	// the line break after the last field is converted into a delimiter by the lexer
+	if err := parser.parseLineBreak(); err != nil {
+		return nil, err
+	}
+	if !parser.matchToken(tokens.RCBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>, ',' or '}'")
+	}
+	return structType, nil
+}
+
+// parseStringInterpolation parses interpolated literals. The literal parts and the values of the embedded expressions
+// are concatenated to a string, therefore each expression has to be of a printable type.
+//
+// stringInterpolation
+//   : INTERPSTART booleanExpression (INTERPMID booleanExpression)* INTERPEND
+//   ;
+func (parser *parser) parseStringInterpolation(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.INTERPSTART) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	for {
+		exprType, err := parserInterface.parseBooleanExpression(parserInterface)
+		if err != nil {
+			return nil, err
+		}
+		if !printable(exprType) {
+			return nil, parser.typeError("Mismatched type '%v' in string interpolation, expected printable type", exprType)
+		}
+		if !parser.lookAHead(tokens.INTERPMID) {
+			break
+		}
+		if !parser.matchToken(tokens.INTERPMID) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+	}
+	if !parser.matchToken(tokens.INTERPEND) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '}'")
+	}
+	return language.NewString(0), nil
+}
+
 // terminal
 //   : NUM
 //   | FLOAT
 //   | TRUE
 //   | FALSE
+//   | NIL
 //   | LITERAL
 //   ;
-func (parser *parser) parseTerminal() error {
-	parser.lineBreakDelimiter = true
+func (parser *parser) parseTerminal() (language.IBasicType, error) {
 	switch {
 	case parser.lookAHead(tokens.NUM):
 		if !parser.matchToken(tokens.NUM) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
+		return language.IntType, nil
 	case parser.lookAHead(tokens.REAL):
 		if !parser.matchToken(tokens.REAL) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
+		return language.FloatType, nil
 	case parser.lookAHead(tokens.TRUE):
 		if !parser.matchToken(tokens.TRUE) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
+		return language.BoolType, nil
 	case parser.lookAHead(tokens.FALSE):
 		if !parser.matchToken(tokens.FALSE) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
+		return language.BoolType, nil
+	case parser.lookAHead(tokens.NIL):
+		if !parser.matchToken(tokens.NIL) {
+			return nil, parser.syntaxError("lexicalError")
+		}
+		return nilPointer, nil
 	case parser.lookAHead(tokens.LITERAL):
 		if !parser.matchToken(tokens.LITERAL) {
-			return parser.syntaxError("lexicalError")
+			return nil, parser.syntaxError("lexicalError")
 		}
+		return literalType(parser.currentToken.GetToken().(tokens.ILiteral)), nil
 	default:
 		_ = parser.matchToken(-1)
-		return parser.syntaxError("Mismatched input '%v', expected <terminal>")
+		return nil, parser.syntaxError("Mismatched input '%v', expected <terminal>")
 	}
-	return nil
 }
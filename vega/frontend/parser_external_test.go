@@ -1,6 +1,9 @@
 package frontend_test
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	. "govega/vega/frontend"
@@ -15,7 +18,7 @@ func TestParser_ParseError(t *testing.T) {
 		{
 			"Misspelled first mandatory keyword",
 			"fonc",
-			"Missing 'func' at 'fonc'",
+			"Missing 'func', 'struct' or <declaration> at 'fonc'",
 		},
 		{
 			"Function keyword and EOF",
@@ -89,17 +92,17 @@ func TestParser_ParseError(t *testing.T) {
 		},
 		{
 			"Missing line delimiter after continue statement",
-			"func test(int []a, int b) int { continue -",
+			"func test(int []a, int b) int { while true { continue -",
 			"Mismatched input '-', expected ';' or line break",
 		},
 		{
 			"Missing line delimiter after break statement",
-			"func test(int []a, int b) int { break -",
+			"func test(int []a, int b) int { while true { break -",
 			"Mismatched input '-', expected ';' or line break",
 		},
 		{
 			"Pass and following statements",
-			"func test(int []a, int b) int { break; pass;",
+			"func test(int []a, int b) int { return 0; pass;",
 			"Mismatched input 'pass', expected <statement> or '}'",
 		},
 		{
@@ -129,7 +132,7 @@ func TestParser_ParseError(t *testing.T) {
 		},
 		{
 			"Missing second elif conditional",
-			"func test(int []a, int b) int { if true { pass; } elif true { break; } elif {",
+			"func test(int []a, int b) int { if true { pass; } elif true { return 0; } elif {",
 			"Mismatched input '{', expected <unary>",
 		},
 		{
@@ -160,7 +163,7 @@ func TestParser_ParseError(t *testing.T) {
 		{
 			"Missing colon after case",
 			"func test() int { switch a { case 1;",
-			"Mismatched input ';', expected ':'",
+			"Mismatched input ';', expected ',' or ':'",
 		},
 		{
 			"No statement in case",
@@ -169,7 +172,7 @@ func TestParser_ParseError(t *testing.T) {
 		},
 		{
 			"No valid second statement in case",
-			"func test() int { switch a { case 1: continue; ;",
+			"func test() int { switch a { case 1: return 1; ;",
 			"Mismatched input ';', expected <statement>, another 'case' or 'default' keyword or '}'",
 		},
 		{
@@ -184,7 +187,7 @@ func TestParser_ParseError(t *testing.T) {
 		},
 		{
 			"No valid second statement after default or closing bracket",
-			"func test() int { switch a { case 1: break; default: continue; ;",
+			"func test() int { switch a { case 1: break; default: return 0; ;",
 			"Mismatched input ';', expected <statement> or '}'",
 		},
 		{
@@ -199,7 +202,7 @@ func TestParser_ParseError(t *testing.T) {
 		},
 		{
 			"Missing return delimiter",
-			"func test(int []a, int b) int { return true }",
+			"func test(int []a, int b) int { return b }",
 			"Mismatched input '}', expected ';' or line break",
 		},
 		{
@@ -215,7 +218,7 @@ func TestParser_ParseError(t *testing.T) {
 		{
 			"No int in array declaration",
 			"func test(int []a, int b) int { int[;",
-			"Mismatched input ';', expected <INT>",
+			"Mismatched input ';', expected <INT> or ']'",
 		},
 		{
 			"No closing array bracket",
@@ -239,13 +242,13 @@ func TestParser_ParseError(t *testing.T) {
 		},
 		{
 			"Missing delimiter after declaration assignment",
-			"func test(int []a, int b) int { int[8] a = 5}",
+			"func test(int []a, int b) int { int c = 5}",
 			"Mismatched input '}', expected ';' or line break",
 		},
 		{
 			"Missing funcCall, array, comma or assignment",
 			"func test(int []a, int b) int { a}",
-			"Mismatched input '}', expected '(', '[', ',' or '='",
+			"Mismatched input '}', expected '(', '[', '.', ',', '=' or <assignment_operator>",
 		},
 		{
 			"Missing funcCall parameter",
@@ -325,7 +328,7 @@ func TestParser_ParseError(t *testing.T) {
 		{
 			"Incomplete array access, closing array bracket",
 			"func test(int []a, int b) int { a = b[4}",
-			"Mismatched input '}', expected ']'",
+			"Mismatched input '}', expected ':' or ']'",
 		},
 		{
 			"String literal not terminated",
@@ -333,127 +336,2084 @@ func TestParser_ParseError(t *testing.T) {
 			"String literal not terminated",
 		},
 		{
-			"Invalid excape sequence",
-			"func test(int []a, int b) int { a = '\\Fd'",
-			"Invalid escape sequence",
+			"Interpolation of non printable type",
+			"func test(int []a, int b) int { int[3] c = [1, 2, 3]; str s = \"c = ${c}\"",
+			"Mismatched type 'int[3]' in string interpolation, expected printable type",
 		},
-	}
-
-	for i, tc := range tests {
-
-		testNumber := i + 1
-
-		vega := NewVega("/path/to/test.vg")
-		lexer := vega.NewLexer([]byte(tc.in))
-		parser := vega.NewParser(lexer)
-		parseErr := parser.Parse(parser)
-
-		if parseErr == nil {
-			t.Fatalf("Test%d: Expected error, got nil", testNumber)
-		}
-
-		switch err := parseErr.(type) {
-		case IVError:
-			if err.GetMessage() != tc.want {
-				t.Fatalf("Test%d: %v:\n\n%v\n\nExpected error message to be:\n\t%v\nbut got:\n\t%v", testNumber, tc.name, tc.in, tc.want, err.GetMessage())
-			}
-		case error:
-			t.Fatalf("Test%d: Expected IVError, but got %v", testNumber, err)
-
-		}
-
-	}
-}
-
-func TestParser_Parse(t *testing.T) {
-	tests := []struct {
-		name string
-		in   string
-	}{
 		{
-			"Full Code test",
-			`
-
-
-/* This is a multiline comment
-which spans over mutiple lines */
-
-// This is a single line comment
-
-// reserved array method
-func fooBar(int[] a, 
-
-bool f) 
-
-
-int
-{
-	a = 1 + 6+ f(4+6) + a[3]
-	b = true == not false != false or false and true
-	const int i; const int a
-	const int i
-	const int g
-
-	switch a {
-	case 'a':
-		return 2
-	case 'b':
-		int a;
-		return 23
-	default:
-		return 0
-	}
-
-	switch a {
-	case 'a':
-		return 2
-	case 'b':
-		int a;
-		return 23
-	}
-
-	return 1
-
-
-}
-
-func main() int {
-	int[5] a = [1, 2, 4, 5, 6 + 8]
-	char c = 'g'
-	str s = '\xFF Hello World'
-	bool a = fooBar()
-	if c == 'g' and a {
-		while true {
-			if c == 'g' {
-				continue
-			} else {
-				break
-			}
-		}
-	} elif false {
-		pass
-	} else {
-		float x = 0.5
-	}
-
-	return 0
-}
-`,
+			"Interpolation of non printable parameter",
+			"func test(int []a, int b) int { str s = \"a = ${b} ${a}\"",
+			"Mismatched type 'int[]' in string interpolation, expected printable type",
 		},
-	}
-
-	for i, tc := range tests {
-
-		testNumber := i + 1
-
-		vega := NewVega("/path/to/test.vg")
-		lexer := vega.NewLexer([]byte(tc.in))
-		parser := vega.NewParser(lexer)
-		parseErr := parser.Parse(parser)
-
-		if parseErr != nil {
-			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
-		}
-
+		{
+			"Missing interpolation expression",
+			"func test(int []a, int b) int { str s = \"a = ${}\"",
+			"Mismatched input '}\"', expected <unary>",
+		},
+		{
+			"Unclosed interpolation expression",
+			"func test(int []a, int b) int { str s = \"a = ${b c}\"",
+			"Mismatched input 'c', expected '}'",
+		},
+		{
+			"Struct without fields",
+			"struct Point {}",
+			"Mismatched input '}', expected <variable_type>",
+		},
+		{
+			"Struct field without name",
+			"struct Point { float x; float }",
+			"Mismatched input '}', expected <identifier> or '['",
+		},
+		{
+			"Duplicate struct field",
+			"struct Point { float x; float x }",
+			"Duplicate field 'x'",
+		},
+		{
+			"Redeclared struct",
+			"struct Point { float x }\nstruct Point { float y }",
+			"Redeclared type 'Point'",
+		},
+		{
+			"Missing declaration after struct",
+			"struct Point { float x } -",
+			"Extraneous input '-', expected EOF, 'func', 'struct' or <declaration>",
+		},
+		{
+			"Unknown field access",
+			"struct Point { float x }\nfunc test(Point p) float { return p.y }",
+			"Unknown field 'y' in type 'Point'",
+		},
+		{
+			"Field access on basic type",
+			"func test(int a) int { return a.x }",
+			"Mismatched type 'int' in field access, expected struct",
+		},
+		{
+			"Missing field name",
+			"struct Point { float x }\nfunc test(Point p) float { p. = 1 }",
+			"Mismatched input '=', expected <identifier>",
+		},
+		{
+			"Unknown field in struct literal",
+			"struct Point { float x }\nfunc test() Point { return Point{y: 1.0} }",
+			"Unknown field 'y' in type 'Point'",
+		},
+		{
+			"Duplicate field in struct literal",
+			"struct Point { float x }\nfunc test() Point { return Point{x: 1.0, x: 2.0} }",
+			"Duplicate field 'x' in struct literal",
+		},
+		{
+			"Mismatched field type in struct literal",
+			"struct Point { float x }\nfunc test() Point { return Point{x: 'abc'} }",
+			"Mismatched type 'str' for field 'x', expected 'float'",
+		},
+		{
+			"Mismatched field type of struct array element",
+			"struct Point { float x }\nfunc test() int { Point[2] ps; bool b = ps[0].x; return 0; }",
+			"Mismatched type 'float', expected 'bool'",
+		},
+		{
+			"Unclosed struct literal",
+			"struct Point { float x }\nfunc test() Point { return Point{x: 1.0 ]",
+			"Mismatched input ']', expected <identifier>, ',' or '}'",
+		},
+		{
+			"Missing for loop delimiter",
+			"func test(int []a, int b) int { for int i = 0 i < b; i = i + 1 {",
+			"Mismatched input 'i', expected ';'",
+		},
+		{
+			"Missing second for loop delimiter",
+			"func test(int []a, int b) int { for ; i < b {",
+			"Mismatched input '{', expected ';'",
+		},
+		{
+			"Missing for loop body",
+			"func test(int []a, int b) int { for ;; i = i + 1 pass",
+			"Mismatched input 'pass', expected '{'",
+		},
+		{
+			"Missing range expression",
+			"func test(int []a, int b) int { for i, v in {",
+			"Mismatched input '{', expected <unary>",
+		},
+		{
+			"Missing in keyword",
+			"func test(int []a, int b) int { for i, v a {",
+			"Mismatched input 'a', expected 'in'",
+		},
+		{
+			"Range over basic type",
+			"func test(int []a, int b) int { for i, v in b {",
+			"Mismatched type 'int' in range loop, expected array, slice or str",
+		},
+		{
+			"Range element is not printable",
+			"func test(int []a, int b) int { int[2][3] m; for row in m { str s = \"${row}\" } }",
+			"Mismatched type 'int[3]' in string interpolation, expected printable type",
+		},
+		{
+			"Loop variable only visible inside the loop",
+			"func test(int []a, int b) int { for a in \"ab\" { str s = \"${a}\"; } str t = \"${a}\"",
+			"Mismatched type 'int[]' in string interpolation, expected printable type",
+		},
+		{
+			"Constant index out of array bounds",
+			"func test(int []a, int b) int { int[3] c; b = c[3]",
+			"Index 3 out of bounds for 'int[3]'",
+		},
+		{
+			"Slice bound out of array bounds",
+			"func test(int []a, int b) int { int[3] c; a = c[1:4]",
+			"Index 4 out of bounds for 'int[3]'",
+		},
+		{
+			"Inverted slice bounds",
+			"func test(int []a, int b) int { a = a[2:1]",
+			"Invalid slice indices 2 > 1",
+		},
+		{
+			"Non integer index",
+			"func test(int []a, int b) int { b = a[1.5]",
+			"Mismatched type 'float' in index, expected 'int'",
+		},
+		{
+			"Index of int",
+			"func test(int []a, int b) int { int c = b[1]",
+			"Mismatched type 'int' in index expression, expected array, slice or str",
+		},
+		{
+			"Assignment to index of float",
+			"func test(int []a, float f) int { f[2] = 3",
+			"Mismatched type 'float' in index expression, expected array, slice or str",
+		},
+		{
+			"Slice expression of int",
+			"func test(int []a, int b) int { a = b[1:]",
+			"Mismatched type 'int' in slice expression, expected array, slice or str",
+		},
+		{
+			"Incomplete slice expression",
+			"func test(int []a, int b) int { a = a[1:2:",
+			"Mismatched input ':', expected ']'",
+		},
+		{
+			"Slice declared after array dimension",
+			"func test(int []a, int b) int { int[2][] c",
+			"Mismatched input ']', expected <INT>",
+		},
+		{
+			"Length of int",
+			"func test(int []a, int b) int { b = len(b)",
+			"Mismatched type 'int' in call of 'len', expected array, slice or str",
+		},
+		{
+			"Capacity of str",
+			"func test(int []a, str s) int { int b = cap(s)",
+			"Mismatched type 'str' in call of 'cap', expected array or slice",
+		},
+		{
+			"Length without argument",
+			"func test(int []a, int b) int { b = len()",
+			"Wrong number of arguments in call of 'len', expected 1",
+		},
+		{
+			"Append to array",
+			"func test(int []a, int b) int { int[2] c; a = append(c, 1)",
+			"Mismatched type 'int[2]' in call of 'append', expected slice",
+		},
+		{
+			"Append value of other type",
+			"func test(int []a, int b) int { append(a, 1, \"x\")",
+			"Mismatched type 'str' in call of 'append', expected 'int'",
+		},
+		{
+			"Append without arguments",
+			"func test(int []a, int b) int { append()",
+			"Wrong number of arguments in call of 'append', expected at least 1",
+		},
+		{
+			"Element of slice of arrays",
+			"func test(int []a, int b) int { int[][3] m; str s = \"${m[0]}\"",
+			"Mismatched type 'int[3]' in string interpolation, expected printable type",
+		},
+		{
+			"Print of array",
+			"func test(int []a, int b) int { println(\"a = \", a)",
+			"Mismatched type 'int[]' in call of 'println', expected printable type",
+		},
+		{
+			"Argument of prelude function with wrong type",
+			"func test(int []a, int b) int { str s = substr(\"abc\", \"b\", 1)",
+			"Mismatched type 'str' for argument 2 of 'substr', expected 'int'",
+		},
+		{
+			"Prelude function with wrong number of arguments",
+			"func test(int []a, int b) int { float f = sqrt(1.0, 2.0)",
+			"Wrong number of arguments in call of 'sqrt', expected 1",
+		},
+		{
+			"Float argument for int parameter",
+			"func test(int []a, int b) int { b = to_int(1.5)",
+			"Mismatched type 'float' for argument 1 of 'to_int', expected 'str'",
+		},
+		{
+			"Maximum of str",
+			"func test(int []a, str s) int { int b = max(1, s)",
+			"Mismatched type 'str' in call of 'max', expected 'int' or 'float'",
+		},
+		{
+			"String of array",
+			"func test(int []a, int b) int { str s = to_str(a)",
+			"Mismatched type 'int[]' in call of 'to_str', expected printable type",
+		},
+		{
+			"Narrowing in declaration",
+			"func test(int []a, int b) int { int c = 1.5",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Narrowing in assignment",
+			"func test(int []a, int b) int { a[0] = 2 * 1.5",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Narrowing of int to char",
+			"func test(int []a, int b) int { char c = b",
+			"Implicit narrowing conversion of 'int' to 'char', use an explicit conversion",
+		},
+		{
+			"Narrowing in return",
+			"func test(int []a, float f) int { return f",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Str in int declaration",
+			"func test(int []a, int b) int { int c = \"hello\"",
+			"Mismatched type 'str', expected 'int'",
+		},
+		{
+			"Bool in int declaration",
+			"func test(int []a, int b) int { int c = true",
+			"Mismatched type 'bool', expected 'int'",
+		},
+		{
+			"Char in int declaration",
+			"func test(int []a, int b) int { int c = 'x'",
+			"Mismatched type 'char', expected 'int'",
+		},
+		{
+			"Str in int assignment",
+			"func test(int []a, int b) int { b = \"hello\"",
+			"Mismatched type 'str', expected 'int'",
+		},
+		{
+			"Bool in int assignment",
+			"func test(int []a, int b) int { b = b == 1",
+			"Mismatched type 'bool', expected 'int'",
+		},
+		{
+			"Char in int assignment",
+			"func test(int []a, int b) int { a[0] = 'x'",
+			"Mismatched type 'char', expected 'int'",
+		},
+		{
+			"Conversion of bool",
+			"func test(int []a, int b) int { b = int(true)",
+			"Cannot convert 'bool' to 'int'",
+		},
+		{
+			"Conversion of str",
+			"func test(int []a, int b) int { float f = float(\"1.5\")",
+			"Cannot convert 'str' to 'float'",
+		},
+		{
+			"Conversion without brackets",
+			"func test(int []a, int b) int { float f = float b",
+			"Mismatched input 'b', expected '('",
+		},
+		{
+			"Arithmetic on char",
+			"func test(int []a, int b) int { b = 'a' + b",
+			"Invalid operands 'char' and 'int' for operator '+'",
+		},
+		{
+			"Subtraction of strings",
+			"func test(int []a, int b) int { str s = \"ab\" - \"b\"",
+			"Invalid operands 'str' and 'str' for operator '-'",
+		},
+		{
+			"Multiplication of bools",
+			"func test(int []a, int b) int { b = b * (b == 1)",
+			"Invalid operands 'int' and 'bool' for operator '*'",
+		},
+		{
+			"Remainder of float",
+			"func test(int []a, int b) int { float f = 1.5 % 2",
+			"Invalid operands 'float' and 'int' for operator '%'",
+		},
+		{
+			"Bitwise and of bool",
+			"func test(int []a, int b) int { b = b & true",
+			"Invalid operands 'int' and 'bool' for operator '&'",
+		},
+		{
+			"Shift of float",
+			"func test(int []a, float f) int { int b = 1 << f",
+			"Invalid operands 'int' and 'float' for operator '<<'",
+		},
+		{
+			"Complement of float",
+			"func test(int []a, int b) int { b = ~1.5",
+			"Invalid operand 'float' for operator '~'",
+		},
+		{
+			"Narrowing in compound assignment",
+			"func test(int []a, int b) int { b += 1.5",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Compound assignment of str",
+			"func test(int []a, int b) int { str s = \"ab\"; s -= \"b\"",
+			"Invalid operands 'str' and 'str' for operator '-='",
+		},
+		{
+			"Increment of str",
+			"func test(int []a, int b) int { str s = \"ab\"; s++",
+			"Invalid operand 'str' for operator '++'",
+		},
+		{
+			"Missing assignment operator after index",
+			"func test(int []a, int b) int { a[0] b",
+			"Mismatched input 'b', expected '(', '[', '.', ',', '=' or <assignment_operator>",
+		},
+		{
+			"Chained comparison",
+			"func test(int []a, int b) int { bool c = 1 < b < 3",
+			"Unexpected '<', comparisons cannot be chained",
+		},
+		{
+			"Chained equality",
+			"func test(int []a, int b) int { bool c = true == false != true",
+			"Unexpected '!=', comparisons cannot be chained",
+		},
+		{
+			"Comparison of str and int",
+			"func test(int []a, int b) int { bool c = \"a\" == b",
+			"Invalid operands 'str' and 'int' for operator '=='",
+		},
+		{
+			"Not applied to int",
+			"func test(int []a, int b) int { bool c = not b",
+			"Invalid operand 'int' for operator 'not'",
+		},
+		{
+			"Not inside arithmetic",
+			"func test(int []a, int b) int { b = 1 + not true",
+			"Mismatched input 'not', expected <unary>",
+		},
+		{
+			"And with int operand",
+			"func test(int []a, int b) int { bool c = b and true",
+			"Invalid operands 'int' and 'bool' for operator 'and'",
+		},
+		{
+			"Or binds weaker than and",
+			"func test(int []a, int b) int { bool c = true || b < 2 && b",
+			"Invalid operands 'bool' and 'int' for operator '&&'",
+		},
+		{
+			"Negation of bool",
+			"func test(int []a, int b) int { bool c = -true",
+			"Invalid operand 'bool' for operator '-'",
+		},
+		{
+			"Int as if condition",
+			"func test(int a) int { if 1 {} return 0; }",
+			"Mismatched type 'int' in condition, expected 'bool'",
+		},
+		{
+			"Str as while condition",
+			"func test(int a) int { while \"s\" {} return 0; }",
+			"Mismatched type 'str' in condition, expected 'bool'",
+		},
+		{
+			"Float as for condition",
+			"func test(int a) int { for ;1.5; {} return 0; }",
+			"Mismatched type 'float' in condition, expected 'bool'",
+		},
+		{
+			"Break outside of loop",
+			"func test(int a) int { if a > 0 { break; } }",
+			"Unexpected 'break' outside of loop or switch",
+		},
+		{
+			"Continue inside switch outside of loop",
+			"func test(int a) int { switch a { case 1: continue; } }",
+			"Unexpected 'continue' outside of loop",
+		},
+		{
+			"Missing return at end of function",
+			"func test(int a) int { a = 1; }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Missing return without else branch",
+			"func test(int a) int { if a > 0 { return 1; } elif a < 0 { return 2; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Missing return in one branch",
+			"func test(int a) int { if a > 0 { return 1; } else { a = 2; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Missing return without default case",
+			"func test(int a) int { switch a { case 1: return 1; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Missing return after break in switch",
+			"func test(int a) int { switch a { case 1: break; default: return 0; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Missing return after loop",
+			"func test(int a) int { while a > 0 { return 1; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Missing return after infinite loop with break",
+			"func test(int a) int { for ;; { break; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Mismatched case type",
+			"func test(int a) int { switch a { case 'x': return 1; } return 0 }",
+			"Mismatched type 'char' in case, expected 'int'",
+		},
+		{
+			"Duplicate case value",
+			"func test(int a) int { switch a { case 1, 2: return 1; case 2: return 2; } return 0 }",
+			"Duplicate case '2'",
+		},
+		{
+			"Duplicate case literal with different quotes",
+			"func test(str a) int { switch a { case 'ab': return 1; case \"ab\": return 2; } return 0 }",
+			"Duplicate case '\"ab\"'",
+		},
+		{
+			"Missing case value after comma",
+			"func test(int a) int { switch a { case 1,: return 1; } return 0 }",
+			"Mismatched input ':', expected <terminal>",
+		},
+		{
+			"Fallthrough in last case",
+			"func test(int a) int { switch a { case 1: fallthrough; } return 0 }",
+			"Unexpected 'fallthrough' in last case of switch",
+		},
+		{
+			"Fallthrough in default case",
+			"func test(int a) int { switch a { case 1: return 1; default: fallthrough; } return 0 }",
+			"Unexpected 'fallthrough' in last case of switch",
+		},
+		{
+			"Statement after fallthrough",
+			"func test(int a) int { switch a { case 1: fallthrough; return 1; case 2: return 2; } return 0 }",
+			"Mismatched input 'return', expected 'case' or 'default' after 'fallthrough'",
+		},
+		{
+			"Fallthrough outside of switch case",
+			"func test(int a) int { switch a { case 1: if a > 0 { fallthrough; } } return 0 }",
+			"Unexpected 'fallthrough' outside of switch case",
+		},
+		{
+			"Missing return after fallthrough",
+			"func test(int a) int { switch a { case 1: fallthrough; default: a = 1; } }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Wrong argument type in call of function value",
+			"func test(func(int) -> int f) int { return f('a') }",
+			"Mismatched type 'char' for argument 1 of 'f', expected 'int'",
+		},
+		{
+			"Wrong argument count in call of function value",
+			"func test(func(int, int) -> bool f) int { bool b = f(1); return 0 }",
+			"Wrong number of arguments in call of 'f', expected 2",
+		},
+		{
+			"Function literal assigned to wrong function type",
+			"func test() int { func(int) -> int f = func(float x) -> int { return 1; }; return 0; }",
+			"Mismatched type 'func(float) -> int', expected 'func(int) -> int'",
+		},
+		{
+			"Missing arrow in function type",
+			"func test(func(int) int f) int { return 0 }",
+			"Mismatched input 'int', expected '->'",
+		},
+		{
+			"Missing return in function literal",
+			"func test() int { func() -> int f = func() -> int { int a = 1; }; return 0 }",
+			"Missing 'return' at '}'",
+		},
+		{
+			"Prelude function without fixed signature as value",
+			"func test() int { func(str) -> int f = len; return 0 }",
+			"Mismatched input ';', expected '('",
+		},
+		{
+			"Break from function literal inside loop",
+			"func test() int { for int i = 0; i < 3; i++ { func() -> int f = func() -> int { break; }; } return 0; }",
+			"Unexpected 'break' outside of loop or switch",
+		},
+		{
+			"Comparison of function values",
+			"func test(func() -> int f, func() -> int g) int { bool b = f == g; return 0 }",
+			"Invalid operands 'func() -> int' and 'func() -> int' for operator '=='",
+		},
+		{
+			"Missing return value",
+			"func test() (int, bool) { return 1; }",
+			"Wrong number of return values, expected 2",
+		},
+		{
+			"Too many return values",
+			"func test() int { return 1, 2; }",
+			"Wrong number of return values, expected 1",
+		},
+		{
+			"Narrowing of return value",
+			"func test() (int, bool) { return 1.5, true; }",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Wrong type of return value",
+			"func test() int { return \"x\"; }",
+			"Mismatched type 'str', expected 'int'",
+		},
+		{
+			"Wrong type of return value in tuple",
+			"func test() (int, bool) { return 1, 2.5; }",
+			"Mismatched type 'float', expected 'bool'",
+		},
+		{
+			"Missing bracket after results",
+			"func test() (int, bool { return 1, true; }",
+			"Mismatched input '{', expected ',' or ')'",
+		},
+		{
+			"Wrong number of variables in destructuring declaration",
+			"func f() (int, int) { return 1, 2; }\nfunc test() int { int a, b, c = f(); return 0; }",
+			"Wrong number of values in assignment, expected 3",
+		},
+		{
+			"Wrong number of values in destructuring assignment",
+			"func test() int { int a, b; a, b = 1; return 0; }",
+			"Wrong number of values in assignment, expected 2",
+		},
+		{
+			"Multiple values in single-value context",
+			"func f() (int, int) { return 1, 2; }\nfunc test() int { int a = f(); return 0; }",
+			"Multiple values '(int, int)' in single-value context",
+		},
+		{
+			"Multiple values as operand",
+			"func f() (int, int) { return 1, 2; }\nfunc test() int { int a = f() + 1; return 0; }",
+			"Invalid operands '(int, int)' and 'int' for operator '+'",
+		},
+		{
+			"Blank identifier as value",
+			"func test() int { int a = _; return 0; }",
+			"Cannot use '_' as value",
+		},
+		{
+			"Compound assignment to blank identifier",
+			"func test() int { _ += 1; return 0; }",
+			"Mismatched input '+=', expected ',' or '='",
+		},
+		{
+			"Parameter without default value after default value",
+			"func f(int a = 1, int b) int { return a; }",
+			"Missing default value for parameter 'b'",
+		},
+		{
+			"Non-constant default value",
+			"int g = 1\nfunc f(int a = g) int { return a; }",
+			"Non-constant default value for parameter 'a'",
+		},
+		{
+			"Default value using parameter",
+			"func f(int a, int b = a + 1) int { return b; }",
+			"Non-constant default value for parameter 'b'",
+		},
+		{
+			"Narrowing default value",
+			"func f(int a = 1.5) int { return a; }",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Wrong type of default value",
+			"func f(int a, int g = \"x\") int { return a; }",
+			"Mismatched type 'str', expected 'int'",
+		},
+		{
+			"Wrong type of argument",
+			"func foo(int a) int { return a; }\nfunc test() int { return foo(\"hello\"); }",
+			"Mismatched type 'str' for argument 1 of 'foo', expected 'int'",
+		},
+		{
+			"Wrong type of named argument",
+			"func f(int a, int b = 1) int { return a; }\nfunc test() int { return f(1, b: true); }",
+			"Mismatched type 'bool' for argument 2 of 'f', expected 'int'",
+		},
+		{
+			"Missing argument",
+			"func f(int a, int b = 1) int { return a; }\nfunc test() int { return f(b: 2); }",
+			"Missing argument 'a' in call of 'f'",
+		},
+		{
+			"Duplicate argument",
+			"func f(int a, int b = 1) int { return a; }\nfunc test() int { return f(1, a: 2); }",
+			"Duplicate argument 'a' in call of 'f'",
+		},
+		{
+			"Unknown argument name",
+			"func f(int a, int b = 1) int { return a; }\nfunc test() int { return f(1, c: 2); }",
+			"Unknown argument 'c' in call of 'f'",
+		},
+		{
+			"Positional argument after named argument",
+			"func f(int a, int b = 1) int { return a; }\nfunc test() int { return f(a: 1, 2); }",
+			"Positional argument after named arguments in call of 'f'",
+		},
+		{
+			"Too many arguments",
+			"func f(int a, int b = 1) int { return a; }\nfunc test() int { return f(1, 2, 3); }",
+			"Wrong number of arguments in call of 'f', expected at most 2",
+		},
+		{
+			"Named argument in call of function value",
+			"func test(func(int) -> int g) int { return g(x: 1); }",
+			"Unknown argument 'x' in call of 'g'",
+		},
+		{
+			"Named argument in call of prelude function",
+			"func test() int { return len(s: \"abc\"); }",
+			"Unknown argument 's' in call of 'len'",
+		},
+		{
+			"Default value in function literal",
+			"func test() int { func(int) -> int g = func(int x = 1) -> int { return x; }; return 0; }",
+			"Unexpected default value for parameter 'x' of function literal",
+		},
+		{
+			"Inferred declaration without value",
+			"func test() int { var x; return 0; }",
+			"Mismatched input ';', expected ',' or '='",
+		},
+		{
+			"Assignment to inferred constant",
+			"func test() int { let x = 1; x += 2; return x; }",
+			"Cannot assign to constant 'x'",
+		},
+		{
+			"Element of inferred str array",
+			"func test() int { var x = [\"a\", \"b\"]; int b = x[0]; return 0; }",
+			"Mismatched type 'str', expected 'int'",
+		},
+		{
+			"Element of str array",
+			"func test() int { str[2] ps; int b = ps[0]; return 0; }",
+			"Mismatched type 'str', expected 'int'",
+		},
+		{
+			"Element of inferred struct array",
+			"struct Point { float x }\nfunc test() int { var ps = [Point{x: 1.0}]; int b = ps[0]; return 0; }",
+			"Mismatched type 'Point', expected 'int'",
+		},
+		{
+			"Inferred declaration of empty array",
+			"func test() int { var a = []; return 0; }",
+			"Cannot infer type of empty array literal, declare the type of the array",
+		},
+		{
+			"Narrowing of inferred type",
+			"func test() int { var n = 1; n = 2.5; return n; }",
+			"Implicit narrowing conversion of 'float' to 'int', use an explicit conversion",
+		},
+		{
+			"Multiple values in inferred declaration",
+			"func pair() (int, int) { return 1, 2; }\nfunc test() int { var a = pair(); return a; }",
+			"Multiple values '(int, int)' in single-value context",
+		},
+		{
+			"Wrong number of inferred values",
+			"func test() int { var a, b, c = 1, 2; return a; }",
+			"Wrong number of values in assignment, expected 3",
+		},
+		{
+			"Mismatched element in array literal",
+			"func test() int { var a = [1, \"b\"]; return 0; }",
+			"Mismatched type 'str' in array literal, expected 'int'",
+		},
+		{
+			"Unused type parameter",
+			"func f[T](int a) int { return a; }",
+			"Type parameter 'T' of 'f' is not used by the parameters",
+		},
+		{
+			"Unknown constraint",
+			"func f[T: sortable](T a) T { return a; }",
+			"Unknown constraint 'sortable', expected 'ordered' or 'numeric'",
+		},
+		{
+			"Unsatisfied constraint",
+			"func max[T: ordered](T a, T b) T { return a; }\nfunc test() bool { return max(true, false); }",
+			"Type 'bool' does not satisfy 'ordered' of type parameter 'T' in call of 'max'",
+		},
+		{
+			"Conflicting type arguments",
+			"func max[T: ordered](T a, T b) T { return a; }\nfunc test() int { return max(1, \"a\"); }",
+			"Mismatched type 'str' for type parameter 'T' in call of 'max', expected 'int'",
+		},
+		{
+			"Arithmetic on ordered type parameter",
+			"func add[T: ordered](T a, T b) T { return a + b; }",
+			"Invalid operands 'T' and 'T' for operator '+'",
+		},
+		{
+			"Ordering of unconstrained type parameter",
+			"func less[T](T a, T b) bool { return a < b; }",
+			"Invalid operands 'T' and 'T' for operator '<'",
+		},
+		{
+			"Generic function as value",
+			"func id[T](T a) T { return a; }\nfunc test() int { func(int) -> int f = id; return 0; }",
+			"Cannot use generic function 'id' without calling it",
+		},
+		{
+			"Recursive instantiation with growing type arguments",
+			"func f[T](T a) int { T[] s; return f(s); }\nfunc test() int { return f(1); }",
+			"Recursive instantiation of 'f' with growing type arguments 'int[]'",
+		},
+		{
+			"Type parameter as value",
+			"func f[T](T a) T { return T; }",
+			"Cannot use type 'T' as value",
+		},
+		{
+			"Literal of type parameter",
+			"func f[T](T a) T { return T{}; }",
+			"Cannot use type 'T' as value",
+		},
+		{
+			"Generic function used before declaration",
+			"func test() int { return id(1); }\nfunc id[T](T a) T { return a; }",
+			"Generic function 'id' is used before its declaration",
+		},
+		{
+			"Type parameter outside of generic function",
+			"func id[T](T a) T { return a; }\nT x",
+			"Extraneous input 'T', expected EOF, 'func', 'struct' or <declaration>",
+		},
+		{
+			"Missing enum variant",
+			"enum Color { Red, Green }\nfunc test() Color { return Color.Yellow; }",
+			"Undefined variant 'Color.Yellow'",
+		},
+		{
+			"Duplicate enum variant",
+			"enum Color { Red, Green, Red }",
+			"Duplicate variant 'Red'",
+		},
+		{
+			"Enum redeclared as struct",
+			"enum Color { Red }\nstruct Color { int r }",
+			"Redeclared type 'Color'",
+		},
+		{
+			"Enum compared with int",
+			"enum Color { Red }\nfunc test(Color c) bool { return c == 0; }",
+			"Invalid operands 'Color' and 'int' for operator '=='",
+		},
+		{
+			"Arithmetic on enum",
+			"enum Color { Red }\nfunc test(Color c) int { return c + 1; }",
+			"Invalid operands 'Color' and 'int' for operator '+'",
+		},
+		{
+			"Case of other enum",
+			"enum Color { Red }\nenum Size { Small }\nfunc test(Color c) int { switch c { case Size.Small: return 1; }; return 0; }",
+			"Mismatched type 'Size' in case, expected 'Color'",
+		},
+		{
+			"Duplicate enum case",
+			"enum Color { Red, Green }\nfunc test(Color c) int { switch c { case Color.Red, Color.Red: return 1; }; return 0; }",
+			"Duplicate case 'Red'",
+		},
+		{
+			"Non-exhaustive switch on enum",
+			"enum Color { Red, Green, Blue }\nfunc test(Color c) int { switch c { case Color.Green: return 1; }; return 0; }",
+			"Missing cases 'Color.Red', 'Color.Blue' in switch on 'Color' without default",
+		},
+		{
+			"Dereference of non-pointer",
+			"func test(int a) int { return *a; }",
+			"Invalid operand 'int' for operator '*'",
+		},
+		{
+			"Dereference of nil",
+			"func test() int { return *nil; }",
+			"Invalid operand 'nil' for operator '*'",
+		},
+		{
+			"Address of constant",
+			"const int c = 1\nfunc test() *int { return &c; }",
+			"Cannot take the address of 'c'",
+		},
+		{
+			"Assignment to global constant",
+			"const int A = 1\nfunc test() int { A = 2; return 0; }",
+			"Cannot assign to constant 'A'",
+		},
+		{
+			"Increment of constant",
+			"func test() int { const int x = 1; x++; return x; }",
+			"Cannot assign to constant 'x'",
+		},
+		{
+			"Compound assignment to constant",
+			"func test() int { const int x = 1; x *= 2; return x; }",
+			"Cannot assign to constant 'x'",
+		},
+		{
+			"Destructuring assignment to constant",
+			"func test() int { const int x = 1; int b; b, x = 1, 2; return x; }",
+			"Cannot assign to constant 'x'",
+		},
+		{
+			"Address of call result",
+			"func test(func() -> int f) *int { return &f(); }",
+			"Cannot take the address of the result of 'f'",
+		},
+		{
+			"Mismatched pointer type",
+			"func test(float f) int { *int p = &f; return 0; }",
+			"Mismatched type '*float', expected '*int'",
+		},
+		{
+			"Nil stored in int",
+			"func test() int { int a = nil; return a; }",
+			"Mismatched type 'nil', expected 'int'",
+		},
+		{
+			"Nil without type",
+			"func test() int { var p = nil; return 0; }",
+			"Cannot infer type of nil, declare the type of the pointer",
+		},
+		{
+			"Ordered pointers",
+			"func test(*int p, *int q) bool { return p < q; }",
+			"Invalid operands '*int' and '*int' for operator '<'",
+		},
+		{
+			"Returned reference to local variable",
+			"func test() *int { int a = 1; return &a; }",
+			"Cannot return reference to local variable",
+		},
+		{
+			"Returned reference to parameter",
+			"func test(int a) *int { *int p = &a; return p; }",
+			"Cannot return reference to local variable",
+		},
+		{
+			"Reference to local variable stored in global",
+			"*int g = nil\nfunc test() int { int a = 1; g = &a; return a; }",
+			"Cannot store reference to local variable in 'g', which outlives the function",
+		},
+		{
+			"Reference to local variable stored through pointer",
+			"func test(**int p) int { int a = 1; *p = &a; return a; }",
+			"Cannot store reference to local variable through pointer",
+		},
+		{
+			"Reference to local variable stored in field through pointer",
+			"struct Node { *Node next }\nfunc test(*Node n) int { Node m = Node{next: nil}; n.next = &m; return 0; }",
+			"Cannot store reference to local variable through pointer",
+		},
+		{
+			"Recursive struct without pointer",
+			"struct Node { int value; Node next }",
+			"Invalid recursive field of type 'Node', use a pointer",
+		},
+		{
+			"Recursive struct array without pointer",
+			"struct Node { int value; Node[2] children }",
+			"Invalid recursive field of type 'Node[2]', use a pointer",
+		},
+		{
+			"Try outside of function returning Result",
+			"func test(str s) int { return try parse_int(s); }",
+			"Unexpected 'try' outside of function returning Result",
+		},
+		{
+			"Try in global initializer",
+			"func f() Result[int] { return ok(1); }\nint a = try f()",
+			"Unexpected 'try' outside of function returning Result",
+		},
+		{
+			"Try on value without result",
+			"func test(int a) Result[int] { return ok(try a); }",
+			"Invalid operand 'int' for operator 'try'",
+		},
+		{
+			"Mismatched result type",
+			"func test(str s) Result[str] { return parse_int(s); }",
+			"Mismatched type 'Result[int]', expected 'Result[str]'",
+		},
+		{
+			"Result without value type",
+			"func test() Result { return fail(\"x\"); }",
+			"Mismatched input '{', expected '['",
+		},
+		{
+			"Unknown field of result",
+			"func test(str s) bool { return parse_int(s).failed; }",
+			"Unknown field 'failed' in type 'Result[int]'",
+		},
+		{
+			"Result used as value",
+			"func test(str s) int { return parse_int(s) + 1; }",
+			"Invalid operands 'Result[int]' and 'int' for operator '+'",
+		},
+		{
+			"Invalid excape sequence",
+			"func test(int []a, int b) int { a = '\\Fd'",
+			"Invalid escape sequence",
+		},
+	}
+
+	for i, tc := range tests {
+
+		testNumber := i + 1
+
+		vega := NewVega("/path/to/test.vg")
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		parseErr := parser.Parse(parser)
+
+		if parseErr == nil {
+			t.Fatalf("Test%d: Expected error, got nil", testNumber)
+		}
+
+		switch err := parseErr.(type) {
+		case IVError:
+			if err.GetMessage() != tc.want {
+				t.Fatalf("Test%d: %v:\n\n%v\n\nExpected error message to be:\n\t%v\nbut got:\n\t%v", testNumber, tc.name, tc.in, tc.want, err.GetMessage())
+			}
+		case error:
+			t.Fatalf("Test%d: Expected IVError, but got %v", testNumber, err)
+
+		}
+
+	}
+}
+
+func TestParser_Parse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{
+			"Full Code test",
+			`
+
+
+/* This is a multiline comment
+which spans over mutiple lines */
+
+// This is a single line comment
+
+// reserved array method
+func fooBar(int[] a, 
+
+bool f) int
+
+
+{
+	a = 1 + 6+ f(4+6) + a[3]
+	b = (true == (not false)) != false or false and true
+	const int i; const int a
+	const int i
+	const int g
+
+	switch a {
+	case 1:
+		return 2
+	case 2:
+		int a;
+		return 23
+	default:
+		return 0
+	}
+
+	switch a {
+	case 1:
+		return 2
+	case 2:
+		int a;
+		return 23
+	}
+
+	return 1
+
+
+}
+
+func main() int {
+	int[5] a = [1, 2, 4, 5, 6 + 8]
+	char c = 'g'
+	str s = '\xFF Hello World'
+	str t = "${s}: c = ${c}, a[0] = ${a[0] + 1}, nested ${"${x}"}"
+	bool a = fooBar(a, true) > 0
+	if c == 'g' and a {
+		while true {
+			if c == 'g' {
+				continue
+			} else {
+				break
+			}
+		}
+	} elif false {
+		pass
+	} else {
+		float x = 0.5
+	}
+
+	return 0
+}
+`,
+		},
+		{
+			"Raw literals",
+			"func main() int {\n" +
+				"\tstr r = `raw ${literal} \\n\n" +
+				"spanning multiple lines`\n" +
+				"\treturn 0\n" +
+				"}\n",
+		},
+		{
+			"For loops",
+			`
+func sum(int[] values, int n) int {
+	int total = 0
+	for int i = 0; i < n; i = i + 1 {
+		if values[i] < 0 {
+			continue
+		}
+		total = total + values[i]
+	}
+	for i, v in values {
+		total = total + i * v
+	}
+	for c in "abc" {
+		str s = "${c}"
+	}
+	int j
+	for j = 0; ; j = j + 1 {
+		break
+	}
+	for ;; {
+		break
+	}
+	return total
+}
+`,
+		},
+		{
+			"Slices",
+			`
+func fill(int[] values, int value) int {
+	for i in values {
+		values[i] = value
+	}
+	return len(values)
+}
+
+func main() int {
+	int[4] numbers = [1, 2, 3, 4]
+	int[] all = numbers[:]
+	int[] middle = numbers[1:3]
+	middle = append(middle, 5, 6)
+	fill(numbers[2:], 0)
+	int[][2] pairs
+	pairs = append(pairs, [1, 2])
+	int[2] pair = pairs[0]
+	str s = "slice"
+	s = s[1:len(s)]
+	return len(all) + cap(middle) + numbers[3] + pair[1]
+}
+`,
+		},
+		{
+			"Conversions",
+			`
+const float half = float(1) / 2
+
+func main() int {
+	int i = 7
+	char c = 'a'
+	float f = float(i) / 2
+	int code = int(c) + int(f * 10.0)
+	char next = char(code + 1)
+	f = i * 2
+	float[3] k
+	k[0] = 32 + 5 * 6
+	return int(float(i) / 3.0 + half)
+}
+`,
+		},
+		{
+			"Operators",
+			`
+func main() int {
+	int a = 7 % 3 + 1 << 2 & 15 | 6 ^ ~3 >> 1
+	a += 2
+	a -= 1
+	a *= 3
+	a /= 2
+	a %= 5
+	a &= 7
+	a |= 8
+	a ^= 1
+	a <<= 2
+	a >>= 1
+	float f = 2.5 + 3 % 2
+	f = 1.5 + 1 << 2
+	f += a
+	f++
+	int[3] k
+	k[0]--
+	for int i = 0; i < 3; i++ {
+		k[i] -= i
+	}
+	str s = "a"
+	s += "b"
+	return a
+}
+`,
+		},
+		{
+			"Boolean precedence",
+			`
+func main() int {
+	bool a = not -5 < 6
+	bool b = true or false and not a
+	bool c = a == b or 1 < 2 && !(3 >= 4)
+	int d = -(1 + 2) * -3
+	if not a and (b or c) {
+		return d
+	}
+	return 0
+}
+`,
+		},
+		{
+			"Prelude",
+			`
+func main() int {
+	println("sum: ", 1 + 2, 1.5, 'c', true)
+	print()
+	str s = concat(substr("hello", 0, 2), to_str(42))
+	int i = find(s, "l") + to_int("7") + len(s)
+	float f = sqrt(2) + pow(2, 3) + floor(1.5) + abs(0 - 1.5) + max(1, 2.5)
+	print("${f} ${min(i, 2)}\n")
+	return i + abs(0 - 3)
+}
+`,
+		},
+		{
+			"Prelude hidden by declarations",
+			`
+func len(int a) int {
+	return a
+}
+
+func main() int {
+	int print = 1
+	return len(print)
+}
+`,
+		},
+		{
+			"Function values",
+			`
+struct Handler { func(int) -> int apply }
+
+func less(int a, int b) bool {
+	return a < b
+}
+
+func sort(int[] values, func(int, int) -> bool before) int[] {
+	for int i = 1; i < len(values); i++ {
+		if before(values[i], values[i-1]) {
+			int swap = values[i]
+			values[i] = values[i-1]
+			values[i-1] = swap
+		}
+	}
+	return values
+}
+
+func counter(int start) func() -> int {
+	int count = start
+	return func() -> int {
+		count++
+		return count
+	}
+}
+
+func main() int {
+	int[3] numbers
+	int[] values = append(numbers[0:0], 3, 1, 2)
+	values = sort(values, less)
+	values = sort(values, func(int a, int b) -> bool { return a > b; })
+	func(int) -> int twice = func(int x) -> int { return x * 2; }
+	func() -> int next = counter(1)
+	Handler h = Handler{apply: twice}
+	func(str, str) -> str join = concat
+	return h.apply(next()) + counter(2)() + len(join("a", "b"))
+}
+`,
+		},
+		{
+			"Multiple return values",
+			`
+const int zero, one = 0, 1
+int lo, hi = bounds()
+
+func bounds() (int, int) {
+	return 0, 10
+}
+
+func divmod(int a, int b) (int, int) {
+	return a / b, a % b
+}
+
+func search(int[] values, int wanted) (int, bool) {
+	for int i = 0; i < len(values); i++ {
+		if values[i] == wanted {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func forward(int a, int b) (int, int) {
+	return divmod(a, b)
+}
+
+func main() int {
+	int q, r = divmod(7, 2)
+	int _, rest = forward(9, 4)
+	int[3] numbers
+	int index, bool found = search(numbers[0:3], 0)
+	float x, y = 0
+	q, r = r, q
+	_, numbers[0] = divmod(q, 3)
+	func(int, int) -> (int, int) split = divmod
+	q, _ = split(1, 2)
+	if found {
+		return index + q + r + rest + zero + one
+	}
+	return lo + hi
+}
+`,
+		},
+		{
+			"Default values and named arguments",
+			`
+const float scale = 2.5
+
+func foobar(float k, int i, int g = 6, float factor = scale * 2) float {
+	return k * i + g * factor
+}
+
+func main() int {
+	float k = 1.2
+	float a = foobar(k, 3)
+	float b = foobar(k: 1.2, i: 3)
+	float c = foobar(k, g: 7, i: 2)
+	float d = foobar(1.2, 3, 4, factor: 0.5)
+	str s = "named"
+	println(a + b + c + d, len(s[1:3]))
+	return 0
+}
+`,
+		},
+		{
+			"Structs",
+			`
+struct Point { float x; float y; }
+
+struct Line {
+	Point start
+	Point end
+	int[2] ids
+}
+
+struct Path {
+	Point[2] ends
+	Line[2][3] segments
+}
+
+func length(Line l) float {
+	float dx = l.end.x - l.start.x
+	return dx
+}
+
+func main() int {
+	Point p = Point{x: 1, y: 2.5}
+	Line l = Line{
+		start: p,
+		end: Point{x: 3.0},
+	}
+	l.end.y = p.x * 2.0
+	l.ids[1] = 4
+	const Point origin = Point{}
+	float d = length(l) + origin.x
+	Path path
+	path.ends = [p, Point{x: d}]
+	path.segments[1][2].end.x = path.ends[0].y
+	return 0
+}
+`,
+		},
+		{
+			"Inferred declarations",
+			`
+func pair() (int, str) {
+	return 1, "a"
+}
+
+func main() int {
+	var x = 3.5
+	let n = 2
+	var values = [1, 2.5, n]
+	var grid = [[1, 2], [3, 4]]
+	var count, name = pair()
+	var names = [name, "b"]
+	var a, b = 0
+	int[] empty = []
+	for var i = 0; i < len(values); i++ {
+		x += values[i] + float(grid[1][0])
+	}
+	a = count + len(names[0]) + len(empty) + b
+	return a
+}
+`,
+		},
+		{
+			"Enumerations",
+			`
+enum Color { Red, Green, Blue }
+
+enum Status {
+	Ok,
+	Failed,
+}
+
+struct Pixel { Color color }
+
+const Color background = Color.Blue
+
+func code(Color c) int {
+	switch c {
+	case Color.Red:
+		return 1
+	case Color.Green, Color.Blue:
+		return 2
+	}
+}
+
+func main() int {
+	Pixel p = Pixel{color: Color.Green}
+	Status s = Status.Ok
+	if p.color == background and s != Status.Failed {
+		return code(p.color)
+	}
+	switch s {
+	case Status.Failed:
+		return 1
+	default:
+		s = Status.Ok
+	}
+	return 0
+}
+`,
+		},
+		{
+			"Pointers",
+			`struct Node {
+	int value
+	*Node next
+}
+
+*Node head = nil
+int counter = 0
+
+func increment(*int p) int {
+	*p += 1
+	return *p
+}
+
+func swap[T](*T a, *T b) T {
+	T t = *a
+	*a = *b
+	*b = t
+	return t
+}
+
+func push(*Node n) *Node {
+	n.next = head
+	head = n
+	return n
+}
+
+func find(*Node n, int value) *Node {
+	while n != nil {
+		if n.value == value {
+			return n
+		}
+		n = n.next
+	}
+	return nil
+}
+
+func main() int {
+	int a = 1
+	int b = 2
+	*int p = &a
+	increment(p)
+	increment(&counter)
+	swap(&a, &b)
+	int[3] values = [1, 2, 3]
+	*int q = &values[1]
+	*q = *p * 2
+	var r = q
+	var pp = &r
+	**pp = 4
+	Node n = Node{value: 1, next: nil}
+	*Node m = find(&n, 1)
+	return *r + m.value + (*p)
+}
+`,
+		},
+		{
+			"Results",
+			`func digit(char c) Result[int] {
+	if c < '0' or c > '9' {
+		return fail("not a digit")
+	}
+	return ok(int(c) - int('0'))
+}
+
+func sum(str[] values) Result[int] {
+	int total = 0
+	for value in values {
+		total += try parse_int(value)
+	}
+	return ok(total)
+}
+
+func check(str s) Result[bool] {
+	try digit(s[0])
+	return ok(true)
+}
+
+func unwrap[T](Result[T] r, T fallback) T {
+	if r.ok {
+		return r.value
+	}
+	return fallback
+}
+
+func main() int {
+	Result[float] f = parse_float("1.5")
+	var r = digit('7')
+	if not r.ok {
+		println(r.error)
+		return 1
+	}
+	str[2] values = ["1", "2"]
+	return unwrap(sum(values), 0) + r.value + int(unwrap(f, 0.0))
+}
+`,
+		},
+	}
+
+	for i, tc := range tests {
+
+		testNumber := i + 1
+
+		vega := NewVega("/path/to/test.vg")
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		parseErr := parser.Parse(parser)
+
+		if parseErr != nil {
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		}
+
+	}
+}
+
+func TestParser_DocComments(t *testing.T) {
+	code := `
+/// add returns the sum of a and b
+/// without overflow checks
+func add(int a, int b) int {
+	return a + b;
+}
+
+// plain comment
+func main() int {
+	return add(1, 2);
+}
+`
+	vega := NewVega("/path/to/test.vg")
+	lexer := vega.NewLexer([]byte(code))
+	parser := vega.NewParser(lexer)
+	if err := parser.Parse(parser); err != nil {
+		t.Fatalf("Expected no error, but got:\n\n%v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"add", "add returns the sum of a and b\nwithout overflow checks"},
+		{"main", ""},
+	}
+
+	for i, tc := range tests {
+		symbol, ok := parser.Lookup(tc.name)
+		if !ok {
+			t.Fatalf("Test%d: Function %v not found", i+1, tc.name)
+		}
+		if symbol.Doc != tc.want {
+			t.Fatalf("Test%d: Want doc of %v to be %q, but got %q", i+1, tc.name, tc.want, symbol.Doc)
+		}
+	}
+}
+
+func TestParser_ControlFlow(t *testing.T) {
+	code := `
+func sign(int a) int {
+	if a < 0 {
+		return 0 - 1
+	} elif a > 0 {
+		return 1
+	} else {
+		return 0
+	}
+}
+
+func pick(int a) int {
+	switch a {
+	case 1:
+		return 10
+		a = 2
+	default:
+		while a > 0 {
+			if a == 3 {
+				break
+				a = 4
+			}
+			continue
+		}
+		return 0
+	}
+	return 1
+}
+
+func spin() int {
+	for ;; {
+		pass
+	}
+}
+`
+	vega := NewVega("/path/to/test.vg")
+	lexer := vega.NewLexer([]byte(code))
+	parser := vega.NewParser(lexer)
+	if err := parser.Parse(parser); err != nil {
+		t.Fatalf("Expected no error, but got:\n\n%v", err)
+	}
+
+	want := []string{
+		"Unreachable statement 'a'",
+		"Unreachable statement 'a'",
+		"Unreachable statement 'return'",
+	}
+	warnings := parser.Warnings()
+	if len(warnings) != len(want) {
+		t.Fatalf("Want %d warnings, but got %v", len(want), warnings)
+	}
+	for i, warning := range warnings {
+		if warning.GetMessage() != want[i] || warning.GetErrorClass() != "Warning" {
+			t.Fatalf("Test%d: Want warning %q, but got %v: %q", i+1, want[i], warning.GetErrorClass(), warning.GetMessage())
+		}
+	}
+}
+
+func TestParser_SwitchSemantics(t *testing.T) {
+	code := `
+func grade(int points) int {
+	switch points {
+	case 1, 2, 3:
+		return 1
+	case 4:
+		points = 5
+		fallthrough
+	case 5:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func check(bool a) int {
+	switch a {
+	case true:
+		return 1
+	case false:
+		return 0
+	}
+}
+
+func name(str s, bool a) int {
+	switch s {
+	case "one", 'two':
+		return 1
+	}
+	switch a {
+	case true:
+		s = "yes"
+	}
+	return 0
+}
+`
+	vega := NewVega("/path/to/test.vg")
+	lexer := vega.NewLexer([]byte(code))
+	parser := vega.NewParser(lexer)
+	if err := parser.Parse(parser); err != nil {
+		t.Fatalf("Expected no error, but got:\n\n%v", err)
+	}
+
+	want := "Missing case 'false' in 'switch' on bool without default"
+	warnings := parser.Warnings()
+	if len(warnings) != 1 || warnings[0].GetMessage() != want {
+		t.Fatalf("Want warning %q, but got %v", want, warnings)
+	}
+}
+
+func TestParser_LineBreakDelimiters(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string // expected error message, empty if the code is valid
+	}{
+		{"Identifier ends statement", "int a = 1\na = b\n", ""},
+		{"Literal ends statement", "str s = \"a\"\nchar c = 'b'\n", ""},
+		{"Closing bracket ends statement", "a = f(1)\nb = c[2]\n", ""},
+		{"Break, continue, pass and return end statements", "while true {\nbreak\n}\nwhile true {\ncontinue\n}\nif true {\npass\n}\n", ""},
+		{"Expression continued after operator", "int a = 1 +\n2 *\n3\n", ""},
+		{"Comparison continued after operator", "bool b = a <\n3 and\ntrue\n", ""},
+		{"Multi-line function call", "f(1,\n2,\n3)\n", ""},
+		{"Multi-line function call with closing bracket on own line", "f(\n1,\n2\n)\n", ""},
+		{"Multi-line function call in expression", "int a = f(\nb +\nc, d\n) + 1\n", ""},
+		{"Multi-line array literal", "int[3] a = [\n1,\n2,\n3\n]\n", ""},
+		{"Multi-line parenthesized expression", "int a = (1\n+ 2\n)\n", ""},
+		{"Multiple empty lines", "int a = 1\n\n\n\nint b = 2\n", ""},
+		{"Semicolon and line break", "int a = 1;\nint b = 2;\n", ""},
+		{"Single line comment before line break", "int a = 1 // comment\nint b = 2\n", ""},
+		{"Multi-line comment acts as line break", "int a = 1 /* multi\nline */ int b = 2\n", ""},
+		{"Single line comment acts as line break", "int a = 1 // int b = 2\nint c = 3\n", ""},
+		{"Single line block comment acts not as line break", "int a = 1 /* comment */ int b = 2\n", "Mismatched input 'int', expected ';' or line break"},
+		{"Statement after if scope", "if a {\npass\n}\nreturn 0\n", ""},
+		{"Else on same line as closing bracket", "if a {\npass\n} else {\npass\n}\n", ""},
+		{"Switch with statements on new lines", "switch a {\ncase 1:\nreturn 1\ndefault:\nreturn 0\n}\n", ""},
+		{"Interpolated literal ends statement", "str s = \"a = ${a}\"\nint b = 1\n", ""},
+		{"Expression continued before operator", "int a = 1\n+ 2\n", "Mismatched input '+', expected <statement> or '}'"},
+		{"Return expression on next line", "return\n1\n", "Mismatched input line break, expected <unary>"},
+		{"Conditional scope on next line", "if a\n{\npass\n}\n", "Mismatched input line break, expected '{'"},
+		{"Else on next line", "if a {\npass\n}\nelse {\npass\n}\n", "Mismatched input 'else', expected <statement> or '}'"},
+		{"Two statements on one line", "int a = 1 int b = 2\n", "Mismatched input 'int', expected ';' or line break"},
+	}
+
+	for i, tc := range tests {
+		testNumber := i + 1
+		code := "func main() int {\n" + tc.in + "return 0\n}\n"
+
+		vega := NewVega("/path/to/test.vg")
+		lexer := vega.NewLexer([]byte(code))
+		parser := vega.NewParser(lexer)
+		parseErr := parser.Parse(parser)
+
+		switch {
+		case tc.want == "" && parseErr != nil:
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		case tc.want != "" && parseErr == nil:
+			t.Fatalf("Test%d: %v: Expected error %q, but got nil", testNumber, tc.name, tc.want)
+		case tc.want != "" && parseErr.(IVError).GetMessage() != tc.want:
+			t.Fatalf("Test%d: %v: Expected error message to be:\n\t%q\nbut got:\n\t%q", testNumber, tc.name, tc.want, parseErr.(IVError).GetMessage())
+		}
+	}
+}
+
+// writeModules creates the files of a project in a temporary directory and returns the directory
+func writeModules(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, code := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(code), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestParser_Modules(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"project/util/text.vg": "func Upper(str s) str {\n\treturn s\n}\n\nfunc lower(str s) str {\n\treturn s\n}\n",
+		"project/math/add.vg":  "func Add(int a, int b) int {\n\treturn sum(a, b)\n}\n",
+		"project/math/sum.vg":  "func sum(int a, int b) int {\n\treturn a + b\n}\n",
+		"project/cycle/a.vg":   "import \"cycle/b\"\n\nfunc A() int {\n\treturn b.B()\n}\n",
+		"project/cycle/b.vg":   "import \"cycle/a\"\n\nfunc B() int {\n\treturn a.A()\n}\n",
+		"project/self.vg":      "import \"main\"\n\nfunc Self() int {\n\treturn 0\n}\n",
+		"project/main.vg":      "import \"self\"\n",
+		"project/config.vg":    "const int Size = 4\nint Count = Size\n",
+		"lib/geo.vg":           "import \"math\"\n\nfunc Area(int w, int h) int {\n\treturn math.Add(w, h)\n}\n",
+	})
+	root := filepath.Join(dir, "project")
+	searchPath := []string{filepath.Join(dir, "lib")}
+
+	tests := []struct {
+		name string
+		in   string
+		want string // expected error message, empty if the code is valid
+	}{
+		{
+			"Qualified calls of single file, package and search path modules",
+			"import \"util/text\"\nimport \"math\"; import \"geo\"\n\nfunc main() int {\n\tstr s = text.Upper(\"a\")\n\ttext.Upper(s)\n\treturn math.Add(geo.Area(1, 2), 3)\n}\n",
+			"",
+		},
+		{"Globals of module", "import \"config\"\nconst int size = config.Size * 2\nint count = config.Count\n", ""},
+		{"Unknown module", "import \"util/missing\"\n", "Cannot find module \"util/missing\""},
+		{"Path leaving the project", "import \"../lib/geo\"\n", "Invalid import path \"../lib/geo\""},
+		{"Path without module name", "import \"util/\"\n", "Invalid import path \"util/\""},
+		{"Module path as char", "import 'm'\n", "Mismatched input ''m'', expected <module_path>"},
+		{"Duplicate module name", "import \"math\"\nimport \"util/math\"\n", "Duplicate import of module \"util/math\""},
+		{"Import after function", "func main() int {\n\treturn 0\n}\nimport \"math\"\n", "Extraneous input 'import', expected EOF, 'func', 'struct' or <declaration>"},
+		{"Private function", "import \"util/text\"\nfunc main() int {\n\ttext.lower('a')\n\treturn 0\n}\n", "Cannot refer to unexported name 'text.lower'"},
+		{"Private function of package in other file", "import \"math\"\nfunc main() int {\n\treturn math.sum(1, 2)\n}\n", "Cannot refer to unexported name 'math.sum'"},
+		{"Undefined name", "import \"math\"\nfunc main() int {\n\treturn math.Sub(1, 2)\n}\n", "Undefined name 'math.Sub'"},
+		{"Module without selector", "import \"math\"\nfunc main() int {\n\treturn math\n}\n", "Mismatched input line break, expected '.'"},
+		{"Assignment to module declaration", "import \"math\"\nfunc main() int {\n\tmath.Add = 1\n\treturn 0\n}\n", "Mismatched input '=', expected '('"},
+		{"Constant initialized with global of module", "import \"config\"\nconst int count = config.Count\n", "Non-constant initializer for global 'count'"},
+		{"Import cycle between modules", "import \"cycle/a\"\n", "Import cycle not allowed: cycle/a -> cycle/b -> cycle/a"},
+		{"Import cycle with importing file", "import \"self\"\n", "Import cycle not allowed: main -> self -> main"},
+	}
+
+	for i, tc := range tests {
+		testNumber := i + 1
+		vega := NewVegaProject(filepath.Join(root, "main.vg"), root, searchPath)
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		parseErr := parser.Parse(parser)
+
+		switch {
+		case tc.want == "" && parseErr != nil:
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		case tc.want != "" && parseErr == nil:
+			t.Fatalf("Test%d: %v: Expected error %q, but got nil", testNumber, tc.name, tc.want)
+		case tc.want != "" && parseErr.(IVError).GetMessage() != tc.want:
+			t.Fatalf("Test%d: %v: Expected error message to be:\n\t%q\nbut got:\n\t%q", testNumber, tc.name, tc.want, parseErr.(IVError).GetMessage())
+		}
+	}
+}
+
+func TestParser_Globals(t *testing.T) {
+	tests := []struct {
+		name   string
+		in     string
+		static bool     // globals have to be initialized with constant expressions
+		want   string   // expected error message, empty if the code is valid
+		order  []string // expected initialization order
+	}{
+		{
+			"Globals depending on later declarations",
+			"int a = b + 1\nint b = f()\nconst int c = 3\n\nfunc f() int {\n\treturn c + d\n}\n\nint d = 2",
+			false, "", []string{"c", "d", "b", "a"},
+		},
+		{
+			"Constants and uninitialized globals",
+			"struct Point { int x }\nconst int a = 1\nconst int b = a * 2; str s\nPoint p = Point{x: b}\n",
+			false, "", []string{"a", "b", "s", "p"},
+		},
+		{
+			"Local variable hides global",
+			"int a = f()\n\nfunc f() int {\n\tint a = 1\n\treturn a\n}\n",
+			false, "", []string{"a"},
+		},
+		{
+			"Global used by function",
+			"func main() int {\n\tcount = count + 1\n\treturn count\n}\n\nint count = 0\n",
+			false, "", []string{"count"},
+		},
+		{
+			"Constant initializers of static globals",
+			"const int a = 2\nint b = a * 3\nstr s = \"a\"\n",
+			true, "", []string{"a", "b", "s"},
+		},
+		{
+			"Destructuring globals",
+			"int a, b = f()\nint c = 1\nint _, d = 2, c\n\nfunc f() (int, int) {\n\treturn c, 2\n}\n",
+			false, "", []string{"c", "a", "b", "d"},
+		},
+		{
+			"Initialization cycle through function",
+			"int a = f()\n\nfunc f() int {\n\treturn a\n}\n",
+			false, "Initialization cycle: a -> f -> a", nil,
+		},
+		{
+			"Initialization cycle between globals",
+			"int a = 1\nint b = c\nint c = g() + b\n\nfunc g() int {\n\treturn a\n}\n",
+			false, "Initialization cycle: b -> c -> b", nil,
+		},
+		{
+			"Global initialized with itself",
+			"int a = a + 1\n",
+			false, "Initialization cycle: a -> a", nil,
+		},
+		{
+			"Constant initialized with function call",
+			"const int a = f()\n\nfunc f() int {\n\treturn 1\n}\n",
+			false, "Non-constant initializer for global 'a'", nil,
+		},
+		{
+			"Constant initialized with prelude function",
+			"const int a = len(\"abc\")\n",
+			false, "Non-constant initializer for global 'a'", nil,
+		},
+		{
+			"Constant initialized with global",
+			"int a = 1\nconst int b = a\n",
+			false, "Non-constant initializer for global 'b'", nil,
+		},
+		{
+			"Function call in static global",
+			"int a = f()\n\nfunc f() int {\n\treturn 1\n}\n",
+			true, "Non-constant initializer for global 'a'", nil,
+		},
+		{
+			"Constant without value",
+			"const int a\n",
+			false, "Mismatched input line break, expected '='", nil,
+		},
+		{
+			"Global redeclared as function",
+			"int a = 1\n\nfunc a() int {\n\treturn 1\n}\n",
+			false, "Redeclared 'a'", nil,
+		},
+		{
+			"Missing delimiter between globals",
+			"int a = 1 int b = 2\n",
+			false, "Mismatched input 'int', expected ';' or line break", nil,
+		},
+	}
+
+	for i, tc := range tests {
+		testNumber := i + 1
+		vega := NewVega("/path/to/test.vg")
+		if tc.static {
+			vega.RequireStaticGlobals()
+		}
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		parseErr := parser.Parse(parser)
+
+		switch {
+		case tc.want == "" && parseErr != nil:
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		case tc.want != "" && parseErr == nil:
+			t.Fatalf("Test%d: %v: Expected error %q, but got nil", testNumber, tc.name, tc.want)
+		case tc.want != "" && parseErr.(IVError).GetMessage() != tc.want:
+			t.Fatalf("Test%d: %v: Expected error message to be:\n\t%q\nbut got:\n\t%q", testNumber, tc.name, tc.want, parseErr.(IVError).GetMessage())
+		case tc.want == "" && !reflect.DeepEqual(parser.InitializationOrder(), tc.order):
+			t.Fatalf("Test%d: %v: Expected initialization order %v, but got %v", testNumber, tc.name, tc.order, parser.InitializationOrder())
+		}
+	}
+}
+
+func TestParser_Closures(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want [][]string // captured variables of each function literal
+	}{
+		{
+			"Literal without captures",
+			"func main() int {\n\tfunc(int) -> int f = func(int x) -> int { return x; }\n\treturn f(1)\n}\n",
+			[][]string{nil},
+		},
+		{
+			"Parameters and locals of the enclosing function",
+			"int total = 0\n\nfunc add(int step) func() -> int {\n\tint count = 0\n\treturn func() -> int {\n\t\tcount += step\n\t\ttotal = count\n\t\treturn count\n\t}\n}\n",
+			[][]string{{"count", "step"}},
+		},
+		{
+			"Nested literals",
+			"func main() int {\n\tint a = 1\n\tfunc() -> int f = func() -> int {\n\t\tint b = 2\n\t\tfunc() -> int g = func() -> int { return a + b; }\n\t\treturn g()\n\t}\n\treturn f()\n}\n",
+			[][]string{{"a"}, {"a", "b"}},
+		},
+	}
+
+	for i, tc := range tests {
+		testNumber := i + 1
+		vega := NewVega("/path/to/test.vg")
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		if parseErr := parser.Parse(parser); parseErr != nil {
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		}
+		if !reflect.DeepEqual(parser.Closures(), tc.want) {
+			t.Fatalf("Test%d: %v: Expected captures %v, but got %v", testNumber, tc.name, tc.want, parser.Closures())
+		}
+	}
+}
+
+func TestParser_LanguageVersions(t *testing.T) {
+	example, err := os.ReadFile("../../resources/specs/syntax_example1.vg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		in   string
+		want string // expected error message, empty if the code is valid
+	}{
+		{"Specification example", string(example), ""},
+		{
+			"Annotated declarations",
+			"struct Point {\n\tx: int\n\ty: int\n}\n\na, b: int, str = 1, \"b\"\nc: const int = 3\n\nfunc f(p: Point, n: int = c) -> (int, bool) {\n\tq, ok: int, bool = p.x + n, true\n\tq, _ = f(p)\n\treturn q, ok\n}\n",
+			"",
+		},
+		{
+			"Function values and loops",
+			"func main() -> int {\n\tg: func(int) -> int = func(x: int) -> int { return x * 2; }\n\tsum: int = 0\n\tfor i: int = 0; i < 3; i += 1 {\n\t\tsum += g(i)\n\t}\n\treturn sum\n}\n",
+			"",
+		},
+		{
+			"Pointers",
+			"func inc(p: *int) -> int {\n\t*p += 1\n\treturn *p\n}\n\nfunc main() -> int {\n\ta: int = 1\n\tp: *int = &a\n\tq: **int = &p\n\t**q = 2\n\treturn inc(p)\n}\n",
+			"",
+		},
+		{
+			"Declarations ending in str",
+			"name: str = \"global\"\n\nstruct Person {\n\tname: str\n\tage: int\n}\n\nfunc main() -> int {\n\ts: str\n\tf: func(int) -> str\n\treturn 0\n}\n",
+			"",
+		},
+		{
+			"Type in front of the name",
+			"func main() -> int {\n\tint a = 1\n\treturn a\n}\n",
+			"Mismatched input 'int', expected 'pass;' or <statement>",
+		},
+		{
+			"Return type without arrow",
+			"func main() int {\n\treturn 0\n}\n",
+			"Mismatched input 'int', expected '->'",
+		},
+		{
+			"Wrong number of types",
+			"func main() -> int {\n\ta, b, c: int, bool = 1, true, 2\n\treturn a\n}\n",
+			"Wrong number of types in declaration, expected 1 or 3",
+		},
+		{
+			"Constant without value",
+			"a: const int\n",
+			"Mismatched input line break, expected '='",
+		},
+	}
+
+	for i, tc := range tests {
+		testNumber := i + 1
+		vega := NewVega("/path/to/test.vg")
+		vega.SetLanguageVersion(Version2)
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		parseErr := parser.Parse(parser)
+
+		switch {
+		case tc.want == "" && parseErr != nil:
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		case tc.want != "" && parseErr == nil:
+			t.Fatalf("Test%d: %v: Expected error %q, but got nil", testNumber, tc.name, tc.want)
+		case tc.want != "" && parseErr.(IVError).GetMessage() != tc.want:
+			t.Fatalf("Test%d: %v: Expected error message to be:\n\t%q\nbut got:\n\t%q", testNumber, tc.name, tc.want, parseErr.(IVError).GetMessage())
+		}
+	}
+}
+
+func TestParser_Generics(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string // instances of the generic functions
+	}{
+		{
+			"Inferred type arguments",
+			"func max[T: ordered](T a, T b) T {\n\tif a > b {\n\t\treturn a\n\t}\n\treturn b\n}\n\nfunc main() int {\n\tint i = max(1, 2)\n\tfloat f = max(1, 2.5)\n\tstr s = max(\"a\", \"b\")\n\treturn max(i, 3)\n}\n",
+			[]string{"max[int]", "max[float]", "max[str]"},
+		},
+		{
+			"Slices and function values",
+			"func apply[T, R](T[] values, func(T) -> R f) R[] {\n\tR[] results = []\n\tfor value in values {\n\t\tresults = append(results, f(value))\n\t}\n\treturn results\n}\n\nfunc main() int {\n\tint[3] a = [1, 2, 3]\n\tstr[] s = apply(a, func(int x) -> str { return to_str(x); })\n\treturn len(s)\n}\n",
+			[]string{"apply[int, str]"},
+		},
+		{
+			"Generic functions calling generic functions",
+			"func add[T: numeric](T a, T b) T {\n\treturn a + b\n}\n\nfunc sum[T: numeric](T[] values) T {\n\tT total = values[0]\n\tfor int i = 1; i < len(values); i++ {\n\t\ttotal = add(total, values[i])\n\t}\n\treturn total\n}\n\nfunc twice[T: numeric](T[] values) T {\n\treturn sum(values) * 2\n}\n\nfunc main() int {\n\tfloat[2] f = [1.5, 2.5]\n\tfloat x = twice(f)\n\treturn add(1, 2)\n}\n",
+			[]string{"twice[float]", "sum[float]", "add[float]", "add[int]"},
+		},
+		{
+			"Recursive generic functions",
+			"func count[T](T[] values, int n) int {\n\tif n == 0 {\n\t\treturn 0\n\t}\n\treturn count(values[1:], n - 1) + 1\n}\n\nfunc main() int {\n\tint[2] a = [1, 2]\n\treturn count(a, 2)\n}\n",
+			[]string{"count[int]"},
+		},
+	}
+
+	for i, tc := range tests {
+		testNumber := i + 1
+		vega := NewVega("/path/to/test.vg")
+		lexer := vega.NewLexer([]byte(tc.in))
+		parser := vega.NewParser(lexer)
+		if parseErr := parser.Parse(parser); parseErr != nil {
+			t.Fatalf("Test%d: %v: Expected no error, but got:\n\n%v", testNumber, tc.name, parseErr)
+		}
+		var instances []string
+		for _, instance := range parser.Instances() {
+			instances = append(instances, instance.String())
+		}
+		if !reflect.DeepEqual(instances, tc.want) {
+			t.Fatalf("Test%d: %v: Expected instances %v, but got %v", testNumber, tc.name, tc.want, instances)
+		}
 	}
 }
@@ -3,6 +3,7 @@ package frontend
 import (
 	"testing"
 
+	"govega/vega/frontend/utils"
 	"govega/vega/language/tokens"
 )
 
@@ -30,6 +31,7 @@ func newTestParser(inputCode []byte) testParserInterface {
 		parser{
 			vega:  v,
 			lexer: l,
+			table: utils.NewSymbolTable(),
 		},
 	}
 	return parser
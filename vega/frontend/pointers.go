@@ -0,0 +1,199 @@
+package frontend
+
+import (
+	"fmt"
+
+	"govega/vega/frontend/utils"
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// nilPointer is the type of nil, it can be stored in pointers of every type
+var nilPointer = language.NewPointer(nil)
+
+// lookAHeadPointerType checks if the next tokens start a pointer type. A star followed by a variable dereferences the
+// variable, two stars start a pointer to a pointer. Statements starting with two stars are dereferences.
+func (parser *parser) lookAHeadPointerType() bool {
+	if !parser.lookAHead(tokens.MULT) {
+		return false
+	}
+	if parser.peekAHead(tokens.MULT) || parser.peekAHead(tokens.BASIC) || parser.peekAHead(tokens.TYPE) || parser.peekAHead(tokens.FUNC) {
+		return true
+	}
+	if parser.peekAHead(tokens.ID) {
+		_, ok := parser.types[parser.peekedToken.GetToken().(tokens.IWord).GetLexeme()]
+		return ok
+	}
+	return false
+}
+
+// parsePointerType parses the type of a pointer, *T is a pointer to a value of type T. The element type of a pointer to
+// an unknown type is unknown as well.
+//
+// pointerType
+//   : MULT terminalVariableType
+//   ;
+func (parser *parser) parsePointerType() (language.IBasicType, error) {
+	if !parser.matchToken(tokens.MULT) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	elementType, err := parser.parseTerminalVariableType()
+	if err != nil || elementType == nil {
+		return nil, err
+	}
+	return language.NewPointer(elementType), nil
+}
+
+// parseAddress parses taking the address of a variable, array element or field. The addresses of constants, functions
+// and results of calls cannot be taken.
+//
+// address
+//   : LOGAND ID (arrayAccess | fieldAccess)*
+//   ;
+func (parser *parser) parseAddress(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.LOGAND) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	if !parser.matchToken(tokens.ID) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected <identifier>")
+	}
+	name := parser.currentToken.GetToken().(tokens.IWord).GetLexeme()
+	if name == blank {
+		return nil, parser.syntaxError("Cannot use '%v' as value")
+	}
+	// declarations of other modules are not addressable
+	if _, ok := parser.imports[name]; ok {
+		return nil, parser.syntaxError("Cannot take the address of '%v'")
+	}
+	parser.reference(name)
+	parser.capture(name)
+	symbol, _ := parser.table.Lookup(name)
+	if symbol != nil && (symbol.Callable || symbol.Const) {
+		return nil, parser.syntaxError("Cannot take the address of '%v'")
+	}
+	local := parser.local(name, symbol) && !parser.indirect(symbol)
+	valueType, called, err := parser.parseAccessOrCall(parserInterface, name, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if called {
+		return nil, parser.typeError("Cannot take the address of the result of '%v'", name)
+	}
+	if local {
+		parser.frameRef = parser.currentToken
+	}
+	if valueType == nil {
+		return nil, nil
+	}
+	return language.NewPointer(valueType), nil
+}
+
+// parseDereference parses the access of the value a pointer refers to and returns the type of the value
+//
+// dereference
+//   : MULT factor
+//   ;
+func (parser *parser) parseDereference(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.MULT) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	operator := parser.currentToken
+	pointerType, err := parserInterface.parseFactor(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	// the value read through the pointer is no reference to the frame even if the pointer is
+	parser.frameRef = nil
+	switch t := pointerType.(type) {
+	case nil:
+		return nil, nil
+	case *language.PointerType:
+		if t != nilPointer {
+			return t.GetElementType(), nil
+		}
+	}
+	return nil, parser.operatorError(operator, pointerType)
+}
+
+// parseDereferenceAssignment parses the assignment of a value to the variable a pointer refers to, the variable may
+// outlive the function and cannot store a reference to a local variable
+//
+// dereferenceAssignment
+//   : dereference assignmentOperator
+//   ;
+func (parser *parser) parseDereferenceAssignment(parserInterface Parser) error {
+	targetType, err := parser.parseDereference(parserInterface)
+	if err != nil {
+		return err
+	}
+	if err := parser.parseAssignmentOperator(parserInterface, targetType, "Mismatched input '%v', expected '=' or <assignment_operator>"); err != nil {
+		return err
+	}
+	if parser.referencesFrame() {
+		return parser.escapeError("Cannot store reference to local variable through pointer")
+	}
+	return nil
+}
+
+// local checks if the variable is stored in the frame of the function whose body is parsed. Variables captured by a
+// function literal are shared with the closure and are not part of the frame.
+func (parser *parser) local(name string, symbol *utils.Symbol) bool {
+	return symbol != nil && !symbol.Callable && !parser.table.IsGlobal(name) && parser.table.Captures(name) == 0
+}
+
+// indirect checks if the accesses following a variable reach memory outside of the variable, which are the fields of a
+// struct referred to by a pointer and the elements of a slice
+func (parser *parser) indirect(symbol *utils.Symbol) bool {
+	if symbol == nil {
+		return false
+	}
+	switch symbol.SymbolType.(type) {
+	case *language.PointerType:
+		return parser.lookAHead(tokens.DOT)
+	case *language.SliceType:
+		return parser.lookAHead(tokens.LSBRACKET)
+	default:
+		return false
+	}
+}
+
+// referencesFrame checks if the expression parsed last refers to a local variable, which is the case when its last
+// token ends the address of a local variable or a variable holding such an address. Local variables only live as long
+// as the call of their function, so such a reference cannot be returned, stored in a global variable or stored through
+// a pointer. References passed through calls or destructuring assignments are not followed.
+func (parser *parser) referencesFrame() bool {
+	return parser.frameRef != nil && parser.frameRef == parser.currentToken
+}
+
+// storeReference checks the assignment of the value parsed last to a variable. A reference to a local variable can
+// only be stored in local variables, which refer to the frame afterwards.
+func (parser *parser) storeReference(name string, symbol *utils.Symbol, indirect bool) error {
+	if !parser.referencesFrame() || symbol == nil {
+		return nil
+	}
+	if indirect {
+		return parser.escapeError("Cannot store reference to local variable through pointer")
+	}
+	if !parser.local(name, symbol) {
+		return parser.escapeError(fmt.Sprintf("Cannot store reference to local variable in '%v', which outlives the function", name))
+	}
+	parser.frameRefs[symbol] = true
+	return nil
+}
+
+// declareReferences marks the declared variables as referring to the frame if their value refers to a local variable
+func (parser *parser) declareReferences(targets []*target) {
+	if !parser.referencesFrame() {
+		return
+	}
+	for _, declared := range targets {
+		if symbol, ok := parser.table.Lookup(declared.name); ok && declared.name != blank {
+			parser.frameRefs[symbol] = true
+		}
+	}
+}
+
+// escapeError returns a vega error on a reference to a local variable which would outlive the call of its function
+func (parser *parser) escapeError(errorMessage string) error {
+	return parser.newParserTypeError(escapingReference, parser.currentToken, errorMessage, parser.lexer.getLineFeed())
+}
@@ -0,0 +1,75 @@
+package frontend
+
+import (
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// failure is the result type of fail, it can be stored in results of every value type
+var failure = language.NewResult(nil)
+
+// parseResultType parses the type parameter of a result, the Result keyword is the current token. A Result[T] either
+// holds a value of type T or a message describing the failure.
+//
+// resultType
+//   : RESULT_TYPE LARRAY parameterType RARRAY
+//   ;
+func (parser *parser) parseResultType() (language.IBasicType, error) {
+	if !parser.matchToken(tokens.LSBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected '['")
+	}
+	valueType, err := parser.parseParameterType(parser)
+	if err != nil {
+		return nil, err
+	}
+	if !parser.matchToken(tokens.RSBRACKET) {
+		return nil, parser.syntaxError("Mismatched input '%v', expected ']'")
+	}
+	if valueType == nil {
+		return nil, nil
+	}
+	return language.NewResult(valueType), nil
+}
+
+// parseTry parses the unwrapping of a result and returns the type of its value. The enclosing function returns a
+// failure to its caller, so it has to return a result as well.
+//
+// try
+//   : TRY unary
+//   ;
+func (parser *parser) parseTry(parserInterface Parser) (language.IBasicType, error) {
+	if !parser.matchToken(tokens.TRY) {
+		return nil, parser.syntaxError("lexicalError")
+	}
+	if _, ok := parser.returnType.(*language.ResultType); !ok {
+		return nil, parser.controlFlowError(invalidControlFlow, "Unexpected '%v' outside of function returning Result")
+	}
+	operator := parser.currentToken
+	resultType, err := parserInterface.parseUnary(parserInterface)
+	if err != nil {
+		return nil, err
+	}
+	switch t := resultType.(type) {
+	case nil:
+		return nil, nil
+	case *language.ResultType:
+		return t.GetValueType(), nil
+	default:
+		return nil, parser.operatorError(operator, resultType)
+	}
+}
+
+// resultField returns the type of a field of a result, the name of the field is the current token. ok reports if the
+// function succeeded, value is its value and error the message of a failure.
+func (parser *parser) resultField(result *language.ResultType) (language.IBasicType, error) {
+	switch parser.currentToken.GetToken().(tokens.IWord).GetLexeme() {
+	case "ok":
+		return language.BoolType, nil
+	case "value":
+		return result.GetValueType(), nil
+	case "error":
+		return stringType, nil
+	default:
+		return nil, parser.typeError("Unknown field '%v' in type '%v'", parser.currentToken.GetToken(), result)
+	}
+}
@@ -0,0 +1,297 @@
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+// typeName returns a readable name of a type for error messages. Types which can not be determined, e.g. because an
+// identifier has not been declared, are represented by nil and are accepted by every check.
+func typeName(t language.IBasicType) string {
+	switch v := t.(type) {
+	case nil:
+		return "unknown"
+	case *language.StringType:
+		return "str"
+	case *language.ArrayType:
+		name := typeName(v.GetType())
+		// arrays of slices and functions are distinguished from slices of arrays and functions returning arrays
+		switch v.GetType().(type) {
+		case *language.SliceType, *language.FunctionType:
+			name = "(" + name + ")"
+		}
+		dimensions := v.GetDimensions()
+		for i := len(dimensions) - 1; i >= 0; i-- {
+			if dimensions[i] == 0 {
+				name += "[]"
+			} else {
+				name += fmt.Sprintf("[%d]", dimensions[i])
+			}
+		}
+		return name
+	case *language.SliceType:
+		// the slice brackets are the outermost dimension, int[][3] is a slice of arrays of three integers
+		name := typeName(v.GetElementType())
+		if i := strings.Index(name, "["); i >= 0 {
+			return name[:i] + "[]" + name[i:]
+		}
+		return name + "[]"
+	case *language.FunctionType:
+		return fmt.Sprintf("func(%v) -> %v", typeNames(v.GetParams()), typeName(v.GetResult()))
+	case *language.TupleType:
+		return fmt.Sprintf("(%v)", typeNames(v.GetTypes()))
+	case *language.ResultType:
+		if v == failure {
+			return "Result"
+		}
+		return fmt.Sprintf("Result[%v]", typeName(v.GetValueType()))
+	case *language.PointerType:
+		if v == nilPointer {
+			return "nil"
+		}
+		// pointers to arrays are distinguished from arrays of pointers, *int[3] is an array of three pointers
+		switch v.GetElementType().(type) {
+		case *language.ArrayType, *language.SliceType:
+			return fmt.Sprintf("*(%v)", typeName(v.GetElementType()))
+		}
+		return "*" + typeName(v.GetElementType())
+	default:
+		return t.GetLexeme()
+	}
+}
+
+// typeNames returns the comma separated names of the types of parameters and results
+func typeNames(types []language.IBasicType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = typeName(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// elementType returns the type of a single element when accessing an array
+func elementType(t language.IBasicType) language.IBasicType {
+	switch v := t.(type) {
+	case *language.StringType:
+		return language.CharType
+	case *language.ArrayType:
+		dimensions := v.GetDimensions()
+		var element language.IBasicType = v.GetType()
+		for _, size := range dimensions[:len(dimensions)-1] {
+			element = language.NewArray(element, size)
+		}
+		return element
+	case *language.SliceType:
+		return v.GetElementType()
+	default:
+		return nil
+	}
+}
+
+// arrayOf returns the type of an array of the given size. The type of an array of unknown elements is unknown, arrays
+// whose elements refer to type parameters are unknown until the function is instantiated.
+func arrayOf(element language.IBasicType, size int) language.IBasicType {
+	if element == nil || refersTo(element, nil) {
+		return nil
+	}
+	return language.NewArray(element, size)
+}
+
+// literalType returns the type of literal, a single character in single quotes is a char, everything else a string
+func literalType(literal tokens.ILiteral) language.IBasicType {
+	content := []rune(literal.GetContent())
+	if content[0] == '\'' && len(content) == 3 {
+		return language.CharType
+	}
+	return language.NewString(len(content) - 2)
+}
+
+// printable checks if values of a type can be converted into a string, type parameters need a constraint which only
+// accepts printable types
+func printable(t language.IBasicType) bool {
+	switch v := t.(type) {
+	case nil, *language.BasicType, *language.StringType:
+		return true
+	case *typeParameter:
+		return v.constraint != constraintAny
+	default:
+		return false
+	}
+}
+
+// numeric checks if arithmetic operations can be applied to values of the type
+func numeric(t language.IBasicType) bool {
+	if typeParam, ok := t.(*typeParameter); ok {
+		return typeParam.constraint == constraintNumeric
+	}
+	return t == nil || t == language.IntType || t == language.FloatType
+}
+
+// binaryType returns the resulting type of arithmetic and bitwise operations and reports if the operator can be
+// applied to the operands. Numbers are promoted according to the table below, strings can only be concatenated with
+// '+'. The remainder, bitwise and shift operators can only be applied to integers. Operations on chars, bools and mixed
+// types like str and int need an explicit conversion of the operands, e.g. int(c). Values of a numeric type parameter
+// can be combined with values of the same type parameter and with integers, the result has the type of the parameter.
+//
+//	+ - * /  | int    float
+//	---------+--------------
+//	int      | int    float
+//	float    | float  float
+//
+//	% & | ^ << >>  | int
+//	---------------+-----
+//	int            | int
+func binaryType(operator int, left language.IBasicType, right language.IBasicType) (language.IBasicType, bool) {
+	if integerOperator(operator) {
+		return language.IntType, integer(left) && integer(right)
+	}
+	if left == nil || right == nil {
+		return nil, true
+	}
+	_, leftString := left.(*language.StringType)
+	_, rightString := right.(*language.StringType)
+	_, leftParam := left.(*typeParameter)
+	_, rightParam := right.(*typeParameter)
+	switch {
+	case operator == tokens.ADD && leftString && rightString:
+		return language.NewString(0), true
+	case !numeric(left) || !numeric(right):
+		return nil, false
+	case leftParam && (right == language.IntType || typeName(left) == typeName(right)):
+		return left, true
+	case rightParam && left == language.IntType:
+		return right, true
+	case leftParam || rightParam:
+		return nil, false
+	case left == language.FloatType || right == language.FloatType:
+		return language.FloatType, true
+	default:
+		return language.IntType, true
+	}
+}
+
+// integer checks if bitwise operations can be applied to values of the type
+func integer(t language.IBasicType) bool {
+	return t == nil || t == language.IntType
+}
+
+// integerOperator checks if the operator can only be applied to integers
+func integerOperator(operator int) bool {
+	switch operator {
+	case tokens.MOD, tokens.LOGAND, tokens.LOGOR, tokens.XOR, tokens.SHL, tokens.SHR, tokens.TILDE:
+		return true
+	default:
+		return false
+	}
+}
+
+// compoundOperators maps the compound assignments to the operators which combine the variable with the value
+var compoundOperators = map[int]int{
+	tokens.ADDASSIGN:  tokens.ADD,
+	tokens.SUBASSIGN:  tokens.SUB,
+	tokens.MULTASSIGN: tokens.MULT,
+	tokens.DIVASSIGN:  tokens.DIV,
+	tokens.MODASSIGN:  tokens.MOD,
+	tokens.ANDASSIGN:  tokens.LOGAND,
+	tokens.ORASSIGN:   tokens.LOGOR,
+	tokens.XORASSIGN:  tokens.XOR,
+	tokens.SHLASSIGN:  tokens.SHL,
+	tokens.SHRASSIGN:  tokens.SHR,
+}
+
+// boolean checks if logical operations can be applied to values of the type
+func boolean(t language.IBasicType) bool {
+	return t == nil || t == language.BoolType
+}
+
+// comparisonOperator checks if the operator compares two values
+func comparisonOperator(operator int) bool {
+	switch operator {
+	case tokens.EQ, tokens.NE, tokens.LESS, tokens.LE, tokens.GREATER, tokens.GE:
+		return true
+	default:
+		return false
+	}
+}
+
+// orderingOperator checks if the operator compares the order of two values
+func orderingOperator(operator int) bool {
+	switch operator {
+	case tokens.LESS, tokens.LE, tokens.GREATER, tokens.GE:
+		return true
+	default:
+		return false
+	}
+}
+
+// comparableTypes checks if values of two types can be compared, numbers can be compared regardless of their type,
+// pointers can be compared with nil and functions cannot be compared
+func comparableTypes(left language.IBasicType, right language.IBasicType) bool {
+	if left == nil || right == nil {
+		return true
+	}
+	if _, ok := left.(*language.FunctionType); ok {
+		return false
+	}
+	if left == nilPointer || right == nilPointer {
+		return assignable(left, right) || assignable(right, left)
+	}
+	if numeric(left) && numeric(right) {
+		return true
+	}
+	return typeName(left) == typeName(right)
+}
+
+// assignable checks if a value of the given type can be stored in a variable or field of the target type, integers are
+// converted into floating point numbers implicitly, arrays into slices referring to the elements of the array, nil
+// into every pointer and failures into every result
+func assignable(target language.IBasicType, value language.IBasicType) bool {
+	if target == nil || value == nil {
+		return true
+	}
+	if _, ok := target.(*language.PointerType); ok && value == nilPointer {
+		return true
+	}
+	if result, ok := target.(*language.ResultType); ok {
+		if valueResult, ok := value.(*language.ResultType); ok {
+			return assignable(result.GetValueType(), valueResult.GetValueType())
+		}
+	}
+	if target == language.FloatType && value == language.IntType {
+		return true
+	}
+	if slice, ok := target.(*language.SliceType); ok {
+		if array, ok := value.(*language.ArrayType); ok {
+			return typeName(slice.GetElementType()) == typeName(elementType(array))
+		}
+	}
+	return typeName(target) == typeName(value)
+}
+
+// narrowing checks if storing a value of the given type in the target would lose precision, these conversions have to
+// be explicit. Integers are narrowed to chars and floating point numbers to integers or chars.
+func narrowing(target language.IBasicType, value language.IBasicType) bool {
+	switch target {
+	case language.IntType:
+		return value == language.FloatType
+	case language.CharType:
+		return value == language.IntType || value == language.FloatType
+	default:
+		return false
+	}
+}
+
+// convertible checks if a value of the given type can be converted explicitly into the target type. Every type can be
+// converted into itself, numbers and chars can be converted into each other.
+func convertible(target language.IBasicType, value language.IBasicType) bool {
+	if value == nil {
+		return true
+	}
+	scalar := func(t language.IBasicType) bool {
+		return t == language.IntType || t == language.FloatType || t == language.CharType
+	}
+	return (scalar(target) && scalar(value)) || typeName(target) == typeName(value)
+}
@@ -0,0 +1,151 @@
+package frontend
+
+import (
+	"fmt"
+	"testing"
+
+	"govega/vega/language"
+	"govega/vega/language/tokens"
+)
+
+func TestTypeChecker_typeName(t *testing.T) {
+	tests := []struct {
+		in   language.IBasicType
+		want string
+	}{
+		{nil, "unknown"},
+		{language.IntType, "int"},
+		{language.NewString(0), "str"},
+		{language.NewArray(language.FloatType, 0), "float[]"},
+		{language.NewArray(language.NewArray(language.IntType, 3), 2), "int[2][3]"},
+		{language.NewSlice(language.NewArray(language.IntType, 3)), "int[][3]"},
+		{language.NewSlice(language.NewSlice(language.CharType)), "char[][]"},
+		{language.NewFunction([]language.IBasicType{language.IntType, language.NewSlice(language.IntType)}, language.BoolType), "func(int, int[]) -> bool"},
+		{language.NewFunction(nil, language.NewFunction(nil, language.IntType)), "func() -> func() -> int"},
+		{language.NewTuple([]language.IBasicType{language.IntType, language.NewString(0)}), "(int, str)"},
+		{language.NewFunction([]language.IBasicType{language.IntType}, language.NewTuple([]language.IBasicType{language.IntType, language.BoolType})), "func(int) -> (int, bool)"},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		if got := typeName(tc.in); got != tc.want {
+			t.Fatalf("%v: Want type name %v, but got %v", test, tc.want, got)
+		}
+	}
+}
+
+func TestTypeChecker_elementType(t *testing.T) {
+	tests := []struct {
+		in   language.IBasicType
+		want string
+	}{
+		{language.IntType, "unknown"},
+		{language.NewString(4), "char"},
+		{language.NewArray(language.IntType, 3), "int"},
+		{language.NewArray(language.NewArray(language.IntType, 3), 2), "int[3]"},
+		{language.NewSlice(language.NewArray(language.IntType, 3)), "int[3]"},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		if got := typeName(elementType(tc.in)); got != tc.want {
+			t.Fatalf("%v: Want element type %v, but got %v", test, tc.want, got)
+		}
+	}
+}
+
+func TestTypeChecker_binaryType(t *testing.T) {
+	tests := []struct {
+		operator int
+		left     language.IBasicType
+		right    language.IBasicType
+		want     string
+		valid    bool
+	}{
+		{tokens.ADD, language.IntType, nil, "unknown", true},
+		{tokens.ADD, language.IntType, language.IntType, "int", true},
+		{tokens.MULT, language.IntType, language.FloatType, "float", true},
+		{tokens.DIV, language.FloatType, language.IntType, "float", true},
+		{tokens.ADD, language.NewString(2), language.NewString(3), "str", true},
+		{tokens.SUB, language.NewString(2), language.NewString(3), "unknown", false},
+		{tokens.ADD, language.CharType, language.IntType, "unknown", false},
+		{tokens.MULT, language.BoolType, language.BoolType, "unknown", false},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		got, valid := binaryType(tc.operator, tc.left, tc.right)
+		if valid != tc.valid {
+			t.Fatalf("%v: Want operands %v and %v to be valid %v, but got %v", test, typeName(tc.left), typeName(tc.right), tc.valid, valid)
+		}
+		if typeName(got) != tc.want {
+			t.Fatalf("%v: Want type %v, but got %v", test, tc.want, typeName(got))
+		}
+	}
+}
+
+func TestTypeChecker_assignable(t *testing.T) {
+	tests := []struct {
+		target language.IBasicType
+		value  language.IBasicType
+		want   bool
+	}{
+		{language.FloatType, language.IntType, true},
+		{language.IntType, language.FloatType, false},
+		{language.NewSlice(language.IntType), language.NewArray(language.IntType, 3), true},
+		{language.NewSlice(language.IntType), language.NewSlice(language.IntType), true},
+		{language.NewSlice(language.FloatType), language.NewArray(language.IntType, 3), false},
+		{language.NewArray(language.IntType, 3), language.NewSlice(language.IntType), false},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		if got := assignable(tc.target, tc.value); got != tc.want {
+			t.Fatalf("%v: Want %v assignable to %v to be %v, but got %v", test, typeName(tc.value), typeName(tc.target), tc.want, got)
+		}
+	}
+}
+
+func TestTypeChecker_narrowing(t *testing.T) {
+	tests := []struct {
+		target language.IBasicType
+		value  language.IBasicType
+		want   bool
+	}{
+		{language.FloatType, language.IntType, false},
+		{language.IntType, language.FloatType, true},
+		{language.CharType, language.IntType, true},
+		{language.IntType, language.CharType, false},
+		{language.IntType, nil, false},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		if got := narrowing(tc.target, tc.value); got != tc.want {
+			t.Fatalf("%v: Want narrowing of %v to %v to be %v, but got %v", test, typeName(tc.value), typeName(tc.target), tc.want, got)
+		}
+	}
+}
+
+func TestTypeChecker_convertible(t *testing.T) {
+	tests := []struct {
+		target language.IBasicType
+		value  language.IBasicType
+		want   bool
+	}{
+		{language.FloatType, language.IntType, true},
+		{language.IntType, language.CharType, true},
+		{language.CharType, language.FloatType, true},
+		{language.BoolType, language.BoolType, true},
+		{language.IntType, language.BoolType, false},
+		{language.BoolType, language.IntType, false},
+		{language.IntType, language.NewString(3), false},
+	}
+
+	for i, tc := range tests {
+		test := fmt.Sprintf("test%d", i+1)
+		if got := convertible(tc.target, tc.value); got != tc.want {
+			t.Fatalf("%v: Want conversion of %v to %v to be %v, but got %v", test, typeName(tc.value), typeName(tc.target), tc.want, got)
+		}
+	}
+}
@@ -10,6 +10,7 @@ type scope struct {
 	hashTable     helper.HashTable // hashTable store all defined variables in the current scope
 	name          string           // give the scope a name
 	previousScope *scope           // link to the previous scope for outer scope lookups
+	function      bool             // the scope holds the parameters of a function literal
 }
 
 // newScope is the internal method for creating a new scope
@@ -45,6 +46,13 @@ func (st *SymbolTable) NewScope(name string) {
 	newScope.previousScope = old
 }
 
+// NewFunctionScope adds a new scope for the parameters of a function literal on top of the SymbolTables last scope.
+// Symbols of the enclosing scopes which are used inside the function literal are captured by the literal.
+func (st *SymbolTable) NewFunctionScope(name string) {
+	st.NewScope(name)
+	st.head.function = true
+}
+
 // LeaveScope removed the current scope from the table
 func (st *SymbolTable) LeaveScope() {
 	if st.getScopeName() != "global" {
@@ -60,10 +68,16 @@ func (st *SymbolTable) getScopeName() string {
 
 // Symbol is stored in the symbol table
 type Symbol struct {
-	name       string              // symbol (identifier) name to be looked up with
-	SymbolType language.IBasicType // identifier data tybe
-	Callable   bool                // flag if identifier is callable (function declaration)
-	Const      bool                // flag if identifier is a constant
+	name       string                // symbol (identifier) name to be looked up with
+	SymbolType language.IBasicType   // identifier data tybe
+	Callable   bool                  // flag if identifier is callable (function declaration)
+	Const      bool                  // flag if identifier is a constant
+	Doc        string                // documentation comment of a function declaration
+	Params     []language.IBasicType // parameter types of a callable symbol if they are checked on calls
+	ParamNames []string              // parameter names of a declared function for named arguments
+	Defaults   int                   // number of trailing parameters of a declared function with default values
+	Builtin    bool                  // flag if identifier is a function of the prelude
+	TypeParams []language.IBasicType // type parameters of a generic function
 }
 
 // NewSymbol creates a new Symbol
@@ -98,3 +112,31 @@ func (st *SymbolTable) Lookup(name string) (entry *Symbol, ok bool) {
 		}
 	}
 }
+
+// IsGlobal checks if the given symbol is found in the global scope and not hidden by a symbol of a nested scope
+func (st *SymbolTable) IsGlobal(name string) bool {
+	for currentScope := st.head; currentScope != nil; currentScope = currentScope.previousScope {
+		if _, ok := currentScope.hashTable.Get(name); ok {
+			return currentScope == st.tail
+		}
+	}
+	return false
+}
+
+// Captures returns the number of function literals which capture the given symbol, these are all function literals
+// between the current scope and the scope declaring the symbol. Global symbols are never captured.
+func (st *SymbolTable) Captures(name string) int {
+	captures := 0
+	for currentScope := st.head; currentScope != nil; currentScope = currentScope.previousScope {
+		if _, ok := currentScope.hashTable.Get(name); ok {
+			if currentScope == st.tail {
+				return 0
+			}
+			return captures
+		}
+		if currentScope.function {
+			captures++
+		}
+	}
+	return 0
+}
@@ -85,3 +85,40 @@ func TestNewScope(t *testing.T) {
 	}
 
 }
+
+func TestSymbolTable_IsGlobal(t *testing.T) {
+	table := NewSymbolTable()
+	table.Add(NewSymbol("global", language.IntType, false, false))
+	table.Add(NewSymbol("hidden", language.IntType, false, false))
+	table.NewScope("main")
+	table.Add(NewSymbol("hidden", language.FloatType, false, false))
+	table.Add(NewSymbol("local", language.IntType, false, false))
+
+	tests := map[string]bool{"global": true, "hidden": false, "local": false, "unknown": false}
+	for name, want := range tests {
+		if got := table.IsGlobal(name); got != want {
+			t.Errorf("IsGlobal(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSymbolTable_Captures(t *testing.T) {
+	table := NewSymbolTable()
+	table.Add(NewSymbol("global", language.IntType, false, false))
+	table.NewScope("main")
+	table.Add(NewSymbol("outer", language.IntType, false, false))
+	table.NewFunctionScope("func")
+	table.Add(NewSymbol("param", language.IntType, false, false))
+	table.NewScope("scope")
+	table.Add(NewSymbol("middle", language.IntType, false, false))
+	table.NewFunctionScope("func")
+	table.NewScope("scope")
+	table.Add(NewSymbol("inner", language.IntType, false, false))
+
+	tests := map[string]int{"global": 0, "outer": 2, "param": 1, "middle": 1, "inner": 0, "unknown": 0}
+	for name, want := range tests {
+		if got := table.Captures(name); got != want {
+			t.Errorf("Captures(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
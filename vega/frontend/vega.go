@@ -1,19 +1,62 @@
 package frontend
 
+import "path/filepath"
+
 type vega struct {
 	file      string
 	codeLines []string
+	modules   *moduleLoader // resolves the imports of all files of a project
 }
 
+// NewVega creates a Vega for a single file, imported modules are resolved relative to the directory of the file
 func NewVega(filePath string) Vega {
+	return NewVegaProject(filePath, filepath.Dir(filePath), nil)
+}
+
+// NewVegaProject creates a Vega for a file of a project, imported modules are resolved relative to the project root
+// and then relative to the directories of the search path
+func NewVegaProject(filePath string, root string, searchPath []string) Vega {
 	var lines []string
 	var v Vega = &vega{
 		file:      filePath,
 		codeLines: lines,
+		modules:   newModuleLoader(root, searchPath),
 	}
 	return v
 }
 
+// LanguageVersion selects the declaration syntax of a project
+type LanguageVersion int
+
+const (
+	Version1 LanguageVersion = iota + 1 // types in front of the names, e.g. int a = 1 and func f(int a) int
+	Version2                            // type annotations after the names, e.g. a: int = 1 and func f(a: int) -> int
+)
+
+// SetLanguageVersion selects the declaration syntax of all files of the project, the default is Version1
+func (v *vega) SetLanguageVersion(version LanguageVersion) {
+	if v.modules == nil {
+		v.modules = newModuleLoader(filepath.Dir(v.file), nil)
+	}
+	v.modules.version = version
+}
+
+// languageVersion returns the declaration syntax of the project
+func (v *vega) languageVersion() LanguageVersion {
+	if v.modules == nil || v.modules.version == 0 {
+		return Version1
+	}
+	return v.modules.version
+}
+
+// RequireStaticGlobals rejects global variables which are not initialized with constant expressions
+func (v *vega) RequireStaticGlobals() {
+	if v.modules == nil {
+		v.modules = newModuleLoader(filepath.Dir(v.file), nil)
+	}
+	v.modules.staticGlobals = true
+}
+
 func (v *vega) getVega() *vega {
 	return v
 }
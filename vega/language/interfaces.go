@@ -24,7 +24,7 @@ func NewBasicType(varType string, tag int, width int) IBasicType {
 type IArrayType interface {
 	IBasicType
 	GetSize() int
-	GetType() *BasicType
+	GetType() IBasicType
 	GetDimensions() []int
 }
 
@@ -44,3 +44,92 @@ func NewString(s int) IStringType {
 	var newString IStringType = newString(s)
 	return newString
 }
+
+// ISliceType interface for SliceType
+type ISliceType interface {
+	IBasicType
+	GetElementType() IBasicType
+}
+
+// NewSlice generates ISliceType interface for SliceType
+func NewSlice(t IBasicType) ISliceType {
+	var newSlice ISliceType = newSlice(t)
+	return newSlice
+}
+
+// IPointerType interface for PointerType
+type IPointerType interface {
+	IBasicType
+	GetElementType() IBasicType
+}
+
+// NewPointer generates IPointerType interface for PointerType
+func NewPointer(t IBasicType) IPointerType {
+	var newPointer IPointerType = newPointer(t)
+	return newPointer
+}
+
+// IResultType interface for ResultType
+type IResultType interface {
+	IBasicType
+	GetValueType() IBasicType
+}
+
+// NewResult generates IResultType interface for ResultType
+func NewResult(t IBasicType) IResultType {
+	var newResult IResultType = newResult(t)
+	return newResult
+}
+
+// IFunctionType interface for FunctionType
+type IFunctionType interface {
+	IBasicType
+	GetParams() []IBasicType
+	GetResult() IBasicType
+}
+
+// NewFunction generates IFunctionType interface for FunctionType
+func NewFunction(params []IBasicType, result IBasicType) IFunctionType {
+	var newFunction IFunctionType = newFunction(params, result)
+	return newFunction
+}
+
+// ITupleType interface for TupleType
+type ITupleType interface {
+	IBasicType
+	GetTypes() []IBasicType
+}
+
+// NewTuple generates ITupleType interface for TupleType
+func NewTuple(types []IBasicType) ITupleType {
+	var newTuple ITupleType = newTuple(types)
+	return newTuple
+}
+
+// IStructType interface for StructType
+type IStructType interface {
+	IBasicType
+	GetFields() []StructField
+	GetField(name string) (StructField, bool)
+	GetAlignment() int
+	SetFields(fields []StructField)
+}
+
+// NewStruct generates IStructType interface for StructType
+func NewStruct(name string, fields []StructField) IStructType {
+	var newStruct IStructType = newStruct(name, fields)
+	return newStruct
+}
+
+// IEnumType interface for EnumType
+type IEnumType interface {
+	IBasicType
+	GetVariants() []string
+	GetVariant(name string) (int, bool)
+}
+
+// NewEnum generates IEnumType interface for EnumType
+func NewEnum(name string, variants []string) IEnumType {
+	var newEnum IEnumType = newEnum(name, variants)
+	return newEnum
+}
@@ -65,3 +65,9 @@ func NewLiteral(c string) ILiteral {
 	var literal ILiteral = newLiteral(c)
 	return literal
 }
+
+// NewStringPart generates new ILiteral interface for a part of an interpolated literal
+func NewStringPart(c string, t int) ILiteral {
+	var literal ILiteral = newStringPart(c, t)
+	return literal
+}
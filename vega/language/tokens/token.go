@@ -21,25 +21,50 @@ const (
 	GE                  // >=
 	NE                  // !=
 	CONST               // const
+	VAR                 // var
+	LET                 // let
 	FUNC                // func
+	STRUCT              // struct
+	ENUM                // enum
+	IMPORT              // import
 	WHILE               // while
+	FOR                 // for
+	IN                  // in
 	IF                  // if
 	ELIF                // elif
 	ELSE                // else
 	SWITCH              // switch
 	CASE                // case
 	DEFAULT             // default
+	FALLTHROUGH         // fallthrough
 	RETURN              // return
+	TRY                 // try
 	PASS                // pass
 	CONTINUE            // continue
 	BREAK               // break
 	TRUE                // true
 	FALSE               // false
+	NIL                 // nil
 	NOT                 // not
 	AND                 // and
 	BOOLAND             // &&
 	OR                  // or
 	BOOLOR              // ||
+	SHL                 // <<
+	SHR                 // >>
+	INC                 // ++
+	DEC                 // --
+	ADDASSIGN           // +=
+	SUBASSIGN           // -=
+	MULTASSIGN          // *=
+	DIVASSIGN           // /=
+	MODASSIGN           // %=
+	ANDASSIGN           // &=
+	ORASSIGN            // |=
+	XORASSIGN           // ^=
+	SHLASSIGN           // <<=
+	SHRASSIGN           // >>=
+	ARROW               // ->
 	INDEX               // [i]
 	ID                  // identifier
 	BASIC               // basic data type (e.g. int, char)
@@ -47,6 +72,9 @@ const (
 	NUM                 // normal numbers (int)
 	REAL                // real numbers (floating point)
 	LITERAL             // everything enclosed in '' or ""
+	INTERPSTART         // part of an interpolated literal in front of the first expression: "...${
+	INTERPMID           // part of an interpolated literal between two expressions: }...${
+	INTERPEND           // part of an interpolated literal after the last expression: }..."
 
 	single_sign_start
 	ASSIGN      // =
@@ -69,6 +97,10 @@ const (
 	LOGOR       // |
 	LOGAND      // &
 	COMMA       // ,
+	DOT         // .
+	MOD         // %
+	XOR         // ^
+	TILDE       // ~
 	single_sign_end
 )
 
@@ -93,6 +125,10 @@ var singleSignString = [...]string{
 	LOGOR:       "|",
 	LOGAND:      "&",
 	COMMA:       ",",
+	DOT:         ".",
+	MOD:         "%",
+	XOR:         "^",
+	TILDE:       "~",
 }
 
 // token struct represents simple basic language tokens identified by an integer number
@@ -210,6 +246,15 @@ func newLiteral(c string) *literal {
 	}
 }
 
+// newStringPart is the constructor for parts of an interpolated literal, the tag defines the position of the part.
+// Like literals the content contains the delimiting characters, e.g. "...${ for the first part.
+func newStringPart(c string, t int) *literal {
+	return &literal{
+		token:   *newToken(t),
+		content: c,
+	}
+}
+
 // GetContent public getter method for the literal content
 func (l *literal) GetContent() string {
 	return l.content
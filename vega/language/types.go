@@ -44,7 +44,7 @@ var (
 type ArrayType struct {
 	BasicType
 	size       int
-	arrayType  *BasicType
+	arrayType  IBasicType
 	dimensions []int
 }
 
@@ -56,14 +56,14 @@ func newArray(t interface{}, s int) *ArrayType {
 	arr := new(ArrayType)
 	arr.size = s
 	switch v := t.(type) {
-	case *BasicType:
-		arr.BasicType = *newBasicType("[]", tokens.INDEX, s*v.width)
-		arr.arrayType = v
-		arr.dimensions = []int{s}
 	case *ArrayType:
 		arr.BasicType = *newBasicType(v.GetLexeme(), v.GetTag(), s*v.GetWidth())
 		arr.arrayType = v.arrayType
-		arr.dimensions = append(v.dimensions, s)
+		arr.dimensions = append(append([]int{}, v.dimensions...), s)
+	case IBasicType:
+		arr.BasicType = *newBasicType("[]", tokens.INDEX, s*v.GetWidth())
+		arr.arrayType = v
+		arr.dimensions = []int{s}
 	}
 	return arr
 }
@@ -73,8 +73,8 @@ func (a *ArrayType) GetSize() int {
 	return a.size
 }
 
-// GetType public getter method for getting the array type
-func (a *ArrayType) GetType() *BasicType {
+// GetType public getter method for getting the type of the elements of the innermost dimension
+func (a *ArrayType) GetType() IBasicType {
 	return a.arrayType
 }
 
@@ -92,3 +92,256 @@ type StringType struct {
 func newString(s int) *StringType {
 	return &StringType{ArrayType: *newArray(CharType, s)}
 }
+
+// pointerWidth is the memory storage space of an address
+const pointerWidth = 8
+
+// SliceType is the data type describing a view into the elements of an array
+//
+// A slice value consists of a pointer to its first element, the length and the capacity, which is the number of
+// elements from the first element up to the end of the underlying array. Arrays are values which are copied when they
+// are assigned, slices share the elements of the array they refer to. Therefore a function with a slice parameter
+// modifies the elements of the array passed by the caller.
+type SliceType struct {
+	BasicType
+	elementType IBasicType
+}
+
+// newSlice is the constructor for new slice types with elements of the given type
+func newSlice(t IBasicType) *SliceType {
+	return &SliceType{
+		BasicType:   *newBasicType("[]", tokens.INDEX, pointerWidth+2*IntType.GetWidth()),
+		elementType: t,
+	}
+}
+
+// GetElementType public getter method for getting the type of the slice elements
+func (s *SliceType) GetElementType() IBasicType {
+	return s.elementType
+}
+
+// PointerType is the data type of addresses of variables, array elements and struct fields
+//
+// A pointer lets a function modify a variable of its caller. The element type is the type of the value the pointer
+// refers to, the pointer itself has the width of an address. The nil pointer, which refers to no value, has no element
+// type.
+type PointerType struct {
+	BasicType
+	elementType IBasicType
+}
+
+// newPointer is the constructor for new pointer types referring to values of the given type
+func newPointer(t IBasicType) *PointerType {
+	return &PointerType{
+		BasicType:   *newBasicType("*", tokens.MULT, pointerWidth),
+		elementType: t,
+	}
+}
+
+// GetElementType public getter method for getting the type of the value the pointer refers to
+func (p *PointerType) GetElementType() IBasicType {
+	return p.elementType
+}
+
+// ResultType is the data type of the results of functions which can fail
+//
+// A result either holds a value of the value type or a message describing the failure. It is stored as the value
+// followed by a pointer to the message, which is nil when the function succeeded. The value type of a failure which
+// can be stored in results of every value type is unknown.
+type ResultType struct {
+	BasicType
+	valueType IBasicType
+}
+
+// newResult is the constructor for new result types holding values of the given type
+func newResult(t IBasicType) *ResultType {
+	width := pointerWidth
+	if t != nil {
+		width += alignTo(t.GetWidth(), pointerWidth)
+	}
+	return &ResultType{
+		BasicType: *newBasicType("Result", tokens.TYPE, width),
+		valueType: t,
+	}
+}
+
+// GetValueType public getter method for getting the type of the value of a successful result
+func (r *ResultType) GetValueType() IBasicType {
+	return r.valueType
+}
+
+// FunctionType is the data type of functions which are stored in variables, passed as arguments or returned
+//
+// A function value is a closure consisting of a pointer to the code of the function and a pointer to the variables it
+// captured from the enclosing functions. Captured variables are shared by reference, an assignment inside the closure
+// is visible to the enclosing function and to all other closures which captured the same variable.
+type FunctionType struct {
+	BasicType
+	params []IBasicType
+	result IBasicType
+}
+
+// newFunction is the constructor for new function types with the given parameter and result types
+func newFunction(params []IBasicType, result IBasicType) *FunctionType {
+	return &FunctionType{
+		BasicType: *newBasicType("func", tokens.FUNC, 2*pointerWidth),
+		params:    params,
+		result:    result,
+	}
+}
+
+// GetParams public getter method for getting the parameter types of the function
+func (f *FunctionType) GetParams() []IBasicType {
+	return f.params
+}
+
+// GetResult public getter method for getting the result type of the function
+func (f *FunctionType) GetResult() IBasicType {
+	return f.result
+}
+
+// TupleType is the result type of functions returning multiple values
+//
+// A tuple is no value of its own, the caller has to destructure the results into separate variables. The values are
+// laid out like the fields of a struct.
+type TupleType struct {
+	BasicType
+	types []IBasicType
+}
+
+// newTuple is the constructor for new tuple types with values of the given types
+func newTuple(types []IBasicType) *TupleType {
+	offset, align := 0, 1
+	for _, t := range types {
+		offset = alignTo(offset, alignment(t)) + t.GetWidth()
+		if alignment(t) > align {
+			align = alignment(t)
+		}
+	}
+	return &TupleType{
+		BasicType: *newBasicType("()", tokens.LBRACKET, alignTo(offset, align)),
+		types:     types,
+	}
+}
+
+// GetTypes public getter method for getting the types of the values in order
+func (t *TupleType) GetTypes() []IBasicType {
+	return t.types
+}
+
+// StructField describes a named member of a struct and its offset from the start of the struct
+type StructField struct {
+	Name   string
+	Type   IBasicType
+	Offset int
+}
+
+// StructType is the data type of user-defined structs
+//
+// The lexeme of the struct type is the name of the struct. Each field is aligned to the alignment of its type and the
+// width of the struct is padded to a multiple of the largest field alignment, so structs can be stored in arrays.
+type StructType struct {
+	BasicType
+	fields    []StructField
+	alignment int
+}
+
+// newStruct is the constructor for new struct types, the offsets of the given fields are calculated
+func newStruct(name string, fields []StructField) *StructType {
+	s := &StructType{BasicType: *newBasicType(name, tokens.TYPE, 0)}
+	s.SetFields(fields)
+	return s
+}
+
+// SetFields public setter method for the fields of a struct, the offsets of the fields and the width of the struct are
+// recalculated. A struct referring to itself through pointers is created without fields, which are set afterwards.
+func (s *StructType) SetFields(fields []StructField) {
+	s.fields, s.alignment = make([]StructField, len(fields)), 1
+	offset := 0
+	for i, field := range fields {
+		align := alignment(field.Type)
+		offset = alignTo(offset, align)
+		s.fields[i] = StructField{Name: field.Name, Type: field.Type, Offset: offset}
+		offset += field.Type.GetWidth()
+		if align > s.alignment {
+			s.alignment = align
+		}
+	}
+	s.width = alignTo(offset, s.alignment)
+}
+
+// GetFields public getter method for getting all struct fields in declaration order
+func (s *StructType) GetFields() []StructField {
+	return s.fields
+}
+
+// GetField public method to retrieve a field by its name
+func (s *StructType) GetField(name string) (StructField, bool) {
+	for _, field := range s.fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return StructField{}, false
+}
+
+// GetAlignment public getter method for the alignment of the struct in memory
+func (s *StructType) GetAlignment() int {
+	return s.alignment
+}
+
+// EnumType is the data type of user-defined enumerations
+//
+// The lexeme of the enum type is the name of the enum. A value of an enum is the index of one of its variants and is
+// stored like an integer.
+type EnumType struct {
+	BasicType
+	variants []string
+}
+
+// newEnum is the constructor for new enum types with the given variants in declaration order
+func newEnum(name string, variants []string) *EnumType {
+	return &EnumType{
+		BasicType: *newBasicType(name, tokens.TYPE, IntType.GetWidth()),
+		variants:  variants,
+	}
+}
+
+// GetVariants public getter method for getting the names of all variants in declaration order
+func (e *EnumType) GetVariants() []string {
+	return e.variants
+}
+
+// GetVariant public method to retrieve the value of a variant by its name
+func (e *EnumType) GetVariant(name string) (int, bool) {
+	for i, variant := range e.variants {
+		if variant == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// alignment returns the alignment of a type, which is the width of the underlying basic type
+func alignment(t IBasicType) int {
+	switch v := t.(type) {
+	case *StructType:
+		return v.alignment
+	case *SliceType, *FunctionType, *PointerType, *ResultType:
+		return pointerWidth
+	case *StringType:
+		return alignment(v.arrayType)
+	case *ArrayType:
+		return alignment(v.arrayType)
+	default:
+		if t.GetWidth() < 1 {
+			return 1
+		}
+		return t.GetWidth()
+	}
+}
+
+// alignTo rounds the offset up to the next multiple of the alignment
+func alignTo(offset int, align int) int {
+	return (offset + align - 1) / align * align
+}
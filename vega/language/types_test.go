@@ -46,6 +46,17 @@ func TestNewArray(t *testing.T) {
 	if !reflect.DeepEqual(a3.GetDimensions(), []int{4, 5, 2}) {
 		t.Fatalf("Want Array3 dimensions to be {4, 5, 2}, got: %v", a2.GetDimensions())
 	}
+
+	point := NewStruct("Point", []StructField{{Name: "x", Type: FloatType}, {Name: "y", Type: FloatType}})
+	points := NewArray(point, 3)
+	if points.GetWidth() != 48 || points.GetType() != point {
+		t.Fatalf("Want Points width 48 and type Point, got: %v and %v", points.GetWidth(), points.GetType())
+	}
+
+	s := NewStruct("S", []StructField{{Name: "flag", Type: BoolType}, {Name: "points", Type: points}})
+	if field, _ := s.GetField("points"); field.Offset != 8 || s.GetWidth() != 56 {
+		t.Fatalf("Want S points offset 8 and width 56, got: %v and %v", field.Offset, s.GetWidth())
+	}
 }
 
 func TestNewString(t *testing.T) {
@@ -62,3 +73,132 @@ func TestNewString(t *testing.T) {
 		t.Fatalf("Want String1 type char, got: %v", s1.GetType())
 	}
 }
+
+func TestNewSlice(t *testing.T) {
+	slice := NewSlice(NewArray(IntType, 3))
+	if slice.GetWidth() != 16 {
+		t.Fatalf("Want slice width 16, got: %v", slice.GetWidth())
+	}
+
+	if element, ok := slice.GetElementType().(IArrayType); !ok || element.GetSize() != 3 {
+		t.Fatalf("Want slice element type int[3], got: %v", slice.GetElementType())
+	}
+
+	s := NewStruct("S", []StructField{{Name: "flag", Type: BoolType}, {Name: "values", Type: slice}})
+	if field, _ := s.GetField("values"); field.Offset != 8 || s.GetWidth() != 24 {
+		t.Fatalf("Want S values offset 8 and width 24, got: %v and %v", field.Offset, s.GetWidth())
+	}
+}
+
+func TestNewPointer(t *testing.T) {
+	pointer := NewPointer(NewArray(CharType, 3))
+	if pointer.GetWidth() != 8 {
+		t.Fatalf("Want pointer width 8, got: %v", pointer.GetWidth())
+	}
+
+	if element, ok := pointer.GetElementType().(IArrayType); !ok || element.GetSize() != 3 {
+		t.Fatalf("Want pointer element type char[3], got: %v", pointer.GetElementType())
+	}
+
+	s := NewStruct("S", nil)
+	s.SetFields([]StructField{{Name: "flag", Type: BoolType}, {Name: "next", Type: NewPointer(s)}})
+	if field, _ := s.GetField("next"); field.Offset != 8 || s.GetWidth() != 16 {
+		t.Fatalf("Want S next offset 8 and width 16, got: %v and %v", field.Offset, s.GetWidth())
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	result := NewResult(IntType)
+	if result.GetWidth() != 16 || result.GetValueType() != IntType {
+		t.Fatalf("Want result width 16 and value type int, got: %v and %v", result.GetWidth(), result.GetValueType())
+	}
+
+	if failure := NewResult(nil); failure.GetWidth() != 8 {
+		t.Fatalf("Want failure width 8, got: %v", failure.GetWidth())
+	}
+
+	s := NewStruct("S", []StructField{{Name: "flag", Type: BoolType}, {Name: "parsed", Type: result}})
+	if field, _ := s.GetField("parsed"); field.Offset != 8 || s.GetWidth() != 24 {
+		t.Fatalf("Want S parsed offset 8 and width 24, got: %v and %v", field.Offset, s.GetWidth())
+	}
+}
+
+func TestNewFunction(t *testing.T) {
+	function := NewFunction([]IBasicType{IntType, IntType}, BoolType)
+	if function.GetWidth() != 16 {
+		t.Fatalf("Want function width 16, got: %v", function.GetWidth())
+	}
+
+	if len(function.GetParams()) != 2 || function.GetResult() != BoolType {
+		t.Fatalf("Want function with two parameters and result bool, got: %v and %v", function.GetParams(), function.GetResult())
+	}
+
+	s := NewStruct("S", []StructField{{Name: "flag", Type: BoolType}, {Name: "less", Type: function}})
+	if field, _ := s.GetField("less"); field.Offset != 8 || s.GetWidth() != 24 {
+		t.Fatalf("Want S less offset 8 and width 24, got: %v and %v", field.Offset, s.GetWidth())
+	}
+}
+
+func TestNewTuple(t *testing.T) {
+	tuple := NewTuple([]IBasicType{IntType, FloatType, BoolType})
+	if tuple.GetWidth() != 24 {
+		t.Fatalf("Want tuple width 24, got: %v", tuple.GetWidth())
+	}
+
+	if len(tuple.GetTypes()) != 3 || tuple.GetTypes()[1] != FloatType {
+		t.Fatalf("Want tuple of int, float and bool, got: %v", tuple.GetTypes())
+	}
+}
+
+func TestNewStruct(t *testing.T) {
+	point := NewStruct("Point", []StructField{{Name: "x", Type: FloatType}, {Name: "y", Type: FloatType}})
+	if point.GetLexeme() != "Point" {
+		t.Fatalf("Want Point lexeme to be Point, got: %v", point.GetLexeme())
+	}
+
+	if point.GetWidth() != 16 {
+		t.Fatalf("Want Point width 16, got: %v", point.GetWidth())
+	}
+
+	s := NewStruct("S", []StructField{
+		{Name: "flag", Type: BoolType},
+		{Name: "count", Type: IntType},
+		{Name: "done", Type: BoolType},
+		{Name: "values", Type: NewArray(IntType, 3)},
+		{Name: "p", Type: point},
+	})
+	var offsets []int
+	for _, field := range s.GetFields() {
+		offsets = append(offsets, field.Offset)
+	}
+	if !reflect.DeepEqual(offsets, []int{0, 4, 8, 12, 24}) {
+		t.Fatalf("Want S offsets to be {0, 4, 8, 12, 24}, got: %v", offsets)
+	}
+
+	if s.GetAlignment() != 8 || s.GetWidth() != 40 {
+		t.Fatalf("Want S alignment 8 and width 40, got: %v and %v", s.GetAlignment(), s.GetWidth())
+	}
+
+	if field, ok := s.GetField("count"); !ok || field.Type != IntType {
+		t.Fatalf("Want S field count of type int, got: %v", field)
+	}
+
+	if _, ok := s.GetField("z"); ok {
+		t.Fatalf("Want S without field z")
+	}
+}
+
+func TestNewEnum(t *testing.T) {
+	color := NewEnum("Color", []string{"Red", "Green", "Blue"})
+	if color.GetLexeme() != "Color" || color.GetWidth() != IntType.GetWidth() {
+		t.Fatalf("Want Color lexeme Color and width of int, got: %v and %v", color.GetLexeme(), color.GetWidth())
+	}
+
+	if value, ok := color.GetVariant("Blue"); !ok || value != 2 {
+		t.Fatalf("Want Color variant Blue with value 2, got: %v", value)
+	}
+
+	if _, ok := color.GetVariant("Yellow"); ok {
+		t.Fatalf("Want Color without variant Yellow")
+	}
+}
@@ -7,35 +7,100 @@
 package language
 
 import (
+	"unicode"
+
 	"govega/vega/helper"
 	"govega/vega/language/tokens"
 )
 
-// define special combined tokens, keywords and special escaped characters to be used by the lexer
+// define special combined tokens and keywords to be used by the lexer
+var (
+	Eq       = tokens.NewWord("==", tokens.EQ)
+	Ne       = tokens.NewWord("!=", tokens.NE)
+	Le       = tokens.NewWord("<=", tokens.LE)
+	Ge       = tokens.NewWord(">=", tokens.GE)
+	BoolAnd  = tokens.NewWord("&&", tokens.BOOLAND)
+	BoolOr   = tokens.NewWord("||", tokens.BOOLOR)
+	KeyWords = initKeyWords()
+)
+
+// Operators maps the first character of operators which can be combined with the following characters to the
+// combined tokens, the lexer uses the longest combination. Operators starting with / are scanned with the comments.
+var Operators = map[rune]map[string]tokens.IWord{
+	'<': {"=": Le, "<": Shl, "<=": ShlAssign},
+	'>': {"=": Ge, ">": Shr, ">=": ShrAssign},
+	'&': {"&": BoolAnd, "=": AndAssign},
+	'|': {"|": BoolOr, "=": OrAssign},
+	'+': {"+": Inc, "=": AddAssign},
+	'-': {"-": Dec, "=": SubAssign, ">": Arrow},
+	'*': {"=": MultAssign},
+	'%': {"=": ModAssign},
+	'^': {"=": XorAssign},
+}
+
+// define operators, assignment operators and the arrow of function types combined from several characters
 var (
-	Eq                    = tokens.NewWord("==", tokens.EQ)
-	Ne                    = tokens.NewWord("!=", tokens.NE)
-	Le                    = tokens.NewWord("<=", tokens.LE)
-	Ge                    = tokens.NewWord(">=", tokens.GE)
-	BoolAnd               = tokens.NewWord("&&", tokens.BOOLAND)
-	BoolOr                = tokens.NewWord("||", tokens.BOOLOR)
-	KeyWords              = initKeyWords()
-	EscapeHexaLiterals    = initHexadecimalChars()
-	EscapeOctalLiterals   = initOctalChars()
-	EscapeUnicodeLiterals = initUnicodeChars()
+	Shl        = tokens.NewWord("<<", tokens.SHL)
+	Shr        = tokens.NewWord(">>", tokens.SHR)
+	Inc        = tokens.NewWord("++", tokens.INC)
+	Dec        = tokens.NewWord("--", tokens.DEC)
+	AddAssign  = tokens.NewWord("+=", tokens.ADDASSIGN)
+	SubAssign  = tokens.NewWord("-=", tokens.SUBASSIGN)
+	MultAssign = tokens.NewWord("*=", tokens.MULTASSIGN)
+	DivAssign  = tokens.NewWord("/=", tokens.DIVASSIGN)
+	ModAssign  = tokens.NewWord("%=", tokens.MODASSIGN)
+	AndAssign  = tokens.NewWord("&=", tokens.ANDASSIGN)
+	OrAssign   = tokens.NewWord("|=", tokens.ORASSIGN)
+	XorAssign  = tokens.NewWord("^=", tokens.XORASSIGN)
+	ShlAssign  = tokens.NewWord("<<=", tokens.SHLASSIGN)
+	ShrAssign  = tokens.NewWord(">>=", tokens.SHRASSIGN)
+	Arrow      = tokens.NewWord("->", tokens.ARROW)
 )
 
+// StatementEndings contains the tags of all tokens which can end a statement. The lexer converts a line break into a
+// delimiter when it follows one of these tokens, similar to the semicolon insertion in Go.
+var StatementEndings = map[int]bool{
+	tokens.ID:          true,
+	tokens.NUM:         true,
+	tokens.REAL:        true,
+	tokens.TRUE:        true,
+	tokens.FALSE:       true,
+	tokens.NIL:         true,
+	tokens.LITERAL:     true,
+	tokens.INTERPEND:   true,
+	tokens.RBRACKET:    true,
+	tokens.RSBRACKET:   true,
+	tokens.RCBRACKET:   true,
+	tokens.RETURN:      true,
+	tokens.BREAK:       true,
+	tokens.CONTINUE:    true,
+	tokens.PASS:        true,
+	tokens.FALLTHROUGH: true,
+	tokens.INC:         true,
+	tokens.DEC:         true,
+}
+
 // initKeyWords creates a new lookup Hashtable containing all the keywords of the language
 func initKeyWords() helper.HashTable {
 	basicTypes := []IBasicType{IntType, FloatType, CharType, BoolType}
 	vocabulary := []tokens.IWord{
 		tokens.NewWord("str", tokens.TYPE),
+		tokens.NewWord("Result", tokens.TYPE),
 		tokens.NewWord("true", tokens.TRUE),
 		tokens.NewWord("false", tokens.FALSE),
+		tokens.NewWord("nil", tokens.NIL),
 		tokens.NewWord("func", tokens.FUNC),
+		tokens.NewWord("struct", tokens.STRUCT),
+		tokens.NewWord("enum", tokens.ENUM),
+		tokens.NewWord("import", tokens.IMPORT),
 		tokens.NewWord("const", tokens.CONST),
+		tokens.NewWord("var", tokens.VAR),
+		tokens.NewWord("let", tokens.LET),
 		tokens.NewWord("return", tokens.RETURN),
+		tokens.NewWord("try", tokens.TRY),
 		tokens.NewWord("while", tokens.WHILE),
+		tokens.NewWord("for", tokens.FOR),
+		tokens.NewWord("in", tokens.IN),
 		tokens.NewWord("break", tokens.BREAK),
 		tokens.NewWord("continue", tokens.CONTINUE),
 		tokens.NewWord("pass", tokens.PASS),
@@ -45,6 +110,7 @@ func initKeyWords() helper.HashTable {
 		tokens.NewWord("switch", tokens.SWITCH),
 		tokens.NewWord("case", tokens.CASE),
 		tokens.NewWord("default", tokens.DEFAULT),
+		tokens.NewWord("fallthrough", tokens.FALLTHROUGH),
 		tokens.NewWord("and", tokens.AND),
 		tokens.NewWord("or", tokens.OR),
 		tokens.NewWord("not", tokens.NOT),
@@ -63,56 +129,34 @@ func initKeyWords() helper.HashTable {
 	return table
 }
 
-// initHexadecimalChars creates a lookup Hashtable for all hexadecimal escaped characters.
+// DecodeEscapeDigits decodes the digits of a numeric escape sequence in the given base, e.g. 16 for \xff or 8 for \123.
 //
-// valid hexadecimal escape sequences are \x00 - \xff. Uppercase will automatically be converted to lowercase.
-func initHexadecimalChars() helper.HashTable {
-	table := helper.NewHashTable()
-	alphabet := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
-	for i := 0; i < 16; i++ {
-		for j := 0; j < 16; j++ {
-			hexKey := string(alphabet[i]) + string(alphabet[j])
-			hexValue := rune(i*16 + j)
-			table.Add(hexKey, hexValue)
-		}
+// Upper- and lowercase hexadecimal digits are accepted. Returns false if a digit is not valid in the base or the value
+// exceeds the unicode range.
+func DecodeEscapeDigits(digits string, base int) (rune, bool) {
+	var value rune
+	if digits == "" {
+		return 0, false
 	}
-	return table
-}
-
-// initOctalChars creates a lookup Hashtable for all octal escaped characters.
-//
-// valid octal escape sequences are \o000 - \0377.
-func initOctalChars() helper.HashTable {
-	table := helper.NewHashTable()
-	alphabet := []rune{'0', '1', '2', '3', '4', '5', '6', '7'}
-	for i := 0; i < 4; i++ {
-		for j := 0; j < 8; j++ {
-			for k := 0; k < 8; k++ {
-				octKey := string(alphabet[i]) + string(alphabet[j]) + string(alphabet[k])
-				octValue := rune(i*8*8 + j*8 + k)
-				table.Add(octKey, octValue)
-			}
+	for _, d := range digits {
+		var digit rune
+		switch {
+		case d >= '0' && d <= '9':
+			digit = d - '0'
+		case d >= 'a' && d <= 'f':
+			digit = d - 'a' + 10
+		case d >= 'A' && d <= 'F':
+			digit = d - 'A' + 10
+		default:
+			return 0, false
 		}
-	}
-	return table
-}
-
-// initUnicodeChars creates a lookup Hashtable for all unicode escaped characters.
-//
-// valid unicode escape sequences are \u0000 - \uffff. Uppercase will automatically be converted to lowercase.
-func initUnicodeChars() helper.HashTable {
-	table := helper.NewHashTable()
-	alphabet := []rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
-	for i := 0; i < 16; i++ {
-		for j := 0; j < 16; j++ {
-			for k := 0; k < 16; k++ {
-				for l := 0; l < 16; l++ {
-					uniKey := string(alphabet[i]) + string(alphabet[j]) + string(alphabet[k]) + string(alphabet[l])
-					uniValue := rune(i*16*16*16 + j*16*16 + k*16 + l)
-					table.Add(uniKey, uniValue)
-				}
-			}
+		if digit >= rune(base) {
+			return 0, false
+		}
+		value = value*rune(base) + digit
+		if value > unicode.MaxRune {
+			return 0, false
 		}
 	}
-	return table
+	return value, true
 }
@@ -36,80 +36,54 @@ func TestKeyWords(t *testing.T) {
 
 }
 
-func TestHexaLiterals(t *testing.T) {
+func TestDecodeEscapeDigits(t *testing.T) {
 	tests := []struct {
 		in   string
+		base int
 		want rune
+		ok   bool
 	}{
-		{"00", rune(00)},
-		{"0a", rune(10)},
-		{"ff", rune(255)},
-	}
-
-	if EscapeHexaLiterals.GetBucketCount() != 256 {
-		t.Fatalf("Table should have 256 entries")
+		{"00", 16, rune(00), true},
+		{"0a", 16, rune(10), true},
+		{"ff", 16, rune(255), true},
+		{"FF", 16, rune(255), true},
+		{"000", 8, rune(000), true},
+		{"123", 8, rune(83), true},
+		{"377", 8, rune(255), true},
+		{"1234", 16, rune(4660), true},
+		{"af53", 16, rune(44883), true},
+		{"12EA", 16, rune(4842), true},
+		{"1F600", 16, rune(0x1F600), true},
+		{"0010FFFF", 16, rune(0x10FFFF), true},
+		{"", 16, 0, false},
+		{"0g", 16, 0, false},
+		{"08", 8, 0, false},
+		{"110000", 16, 0, false},
+		{"FFFFFFFF", 16, 0, false},
 	}
 
 	for i, tc := range tests {
-		hex, ok := EscapeHexaLiterals.Get(tc.in)
-		if !ok {
-			t.Fatalf("test%d: Element %v not found", i, tc.in)
+		got, ok := DecodeEscapeDigits(tc.in, tc.base)
+		if ok != tc.ok {
+			t.Fatalf("test%d: Want %v to be decodable: %v, but got %v", i, tc.in, tc.ok, ok)
 		}
-		hexRune := hex.(rune)
-		if hexRune != tc.want {
-			t.Fatalf("test%d: Want %v, but got %v", i, tc.want, hexRune)
+		if got != tc.want {
+			t.Fatalf("test%d: Want %v, but got %v", i, tc.want, got)
 		}
 	}
 }
 
-func TestOctaLiterals(t *testing.T) {
-	tests := []struct {
-		in   string
-		want rune
-	}{
-		{"000", rune(000)},
-		{"123", rune(83)},
-		{"377", rune(255)},
-	}
-
-	if EscapeOctalLiterals.GetBucketCount() != 256 {
-		t.Fatalf("Table should have 256 entries")
-	}
-
-	for i, tc := range tests {
-		oct, ok := EscapeOctalLiterals.Get(tc.in)
-		if !ok {
-			t.Fatalf("test%d: Element %v not found", i, tc.in)
-		}
-		octRune := oct.(rune)
-		if octRune != tc.want {
-			t.Fatalf("test%d: Want %v, but got %v", i, tc.want, octRune)
-		}
+// BenchmarkInit measures the work done when initialising the package, which is only creating the keyword table
+func BenchmarkInit(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		initKeyWords()
 	}
 }
 
-func TestUnicodeLiterals(t *testing.T) {
-	tests := []struct {
-		in   string
-		want rune
-	}{
-		{"1234", rune(4660)},
-		{"af53", rune(44883)},
-		{"12ea", rune(4842)},
-	}
-
-	if EscapeUnicodeLiterals.GetBucketCount() != 65536 {
-		t.Fatalf("Table should have 65535 entries, but it has: %v entries", EscapeUnicodeLiterals.GetBucketCount())
-	}
-
-	for i, tc := range tests {
-		unicode, ok := EscapeUnicodeLiterals.Get(tc.in)
-		if !ok {
-			t.Fatalf("test%d: Element %v not found", i, tc.in)
-		}
-		unicodeRune := unicode.(rune)
-		if unicodeRune != tc.want {
-			t.Fatalf("test%d: Want %v, but got %v", i, tc.want, unicodeRune)
-		}
+func BenchmarkDecodeEscapeDigits(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DecodeEscapeDigits("1F600", 16)
 	}
 }